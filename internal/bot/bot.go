@@ -1,7 +1,9 @@
 package bot
 
 import (
+	"context"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gempir/gempbot/internal/bot/commander"
@@ -10,7 +12,10 @@ import (
 	"github.com/gempir/gempbot/internal/dto"
 	"github.com/gempir/gempbot/internal/helixclient"
 	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/messages"
 	"github.com/gempir/gempbot/internal/store"
+	"github.com/gempir/go-twitch-irc/v4"
+	"github.com/puzpuzpuz/xsync"
 )
 
 // Bot basic logging bot
@@ -22,32 +27,79 @@ type Bot struct {
 	listener    *commander.Listener
 	Done        chan bool
 	ChatClient  *chat.ChatClient
+	Messenger   *messages.Messenger
+
+	messageHandlersMu sync.RWMutex
+	messageHandlers   []func(twitch.PrivateMessage)
+
+	// channelIDs of the joined channels by login, join states only know the login
+	channelIDs *xsync.MapOf[string, string]
 }
 
 func NewBot(cfg *config.Config, db *store.Database, helixClient helixclient.Client) *Bot {
 	chatClient := chat.NewClient(cfg)
 
-	handler := commander.NewHandler(cfg, helixClient, db, chatClient.Say)
+	messenger := messages.NewMessenger(db, chatClient.Say)
+
+	handler := commander.NewHandler(cfg, helixClient, db, messenger)
 
-	listener := commander.NewListener(db, handler, chatClient.Say)
+	listener := commander.NewListener(db, handler, chatClient.Say, messenger)
 	listener.RegisterDefaultCommands()
 
-	return &Bot{
+	b := &Bot{
 		Done:        make(chan bool),
 		ChatClient:  chatClient,
+		Messenger:   messenger,
 		cfg:         cfg,
 		db:          db,
 		listener:    listener,
 		helixClient: helixClient,
+		channelIDs:  xsync.NewMapOf[string](),
 	}
+	chatClient.SetOnJoinStateChange(b.handleJoinStateChange)
+
+	return b
 }
 
-func (b *Bot) RegisterCommand(command string, handler func(dto.CommandPayload)) {
-	b.listener.RegisterCommand(command, handler)
+func (b *Bot) RegisterCommand(command dto.Command) {
+	b.listener.RegisterCommand(command)
+}
+
+// Commands lists the built-in and custom commands of a channel
+func (b *Bot) Commands(channelID string) []dto.CommandInfo {
+	return b.listener.Commands(channelID)
+}
+
+func (b *Bot) HasCommand(command string) bool {
+	return b.listener.HasCommand(command)
+}
+
+// ReloadChannelConfig refreshes the cached commands, settings and permissions of a channel
+func (b *Bot) ReloadChannelConfig(channelID string) {
+	b.listener.LoadChannelConfig(channelID)
+}
+
+// OnPrivateMessage registers an additional handler for every chat message
+func (b *Bot) OnPrivateMessage(handler func(twitch.PrivateMessage)) {
+	b.messageHandlersMu.Lock()
+	defer b.messageHandlersMu.Unlock()
+
+	b.messageHandlers = append(b.messageHandlers, handler)
+}
+
+func (b *Bot) handlePrivateMessage(msg twitch.PrivateMessage) {
+	b.listener.HandlePrivateMessage(msg)
+
+	b.messageHandlersMu.RLock()
+	defer b.messageHandlersMu.RUnlock()
+
+	for _, handler := range b.messageHandlers {
+		handler(msg)
+	}
 }
 
 func (b *Bot) Say(channel string, message string) {
-	go b.ChatClient.Say(channel, message)
+	b.ChatClient.Say(channel, message)
 }
 
 func (b *Bot) SayByChannelID(channelID string, message string) {
@@ -57,14 +109,15 @@ func (b *Bot) SayByChannelID(channelID string, message string) {
 		return
 	}
 
-	go b.ChatClient.Say(userData.Login, message)
+	b.ChatClient.Say(userData.Login, message)
 }
 
 func (c *Bot) Reply(channel string, parentMsgId string, message string) {
 	c.ChatClient.Reply(channel, parentMsgId, message)
 }
 
-func (b *Bot) Join(channel string) {
+func (b *Bot) Join(channelID string, channel string) {
+	b.channelIDs.Store(strings.ToLower(channel), channelID)
 	go b.ChatClient.Join(channel)
 }
 
@@ -74,7 +127,7 @@ func (b *Bot) Part(channel string) {
 
 func (b *Bot) Connect() {
 	b.startTime = time.Now()
-	b.ChatClient.SetOnPrivateMessage(b.listener.HandlePrivateMessage)
+	b.ChatClient.SetOnPrivateMessage(b.handlePrivateMessage)
 	go b.ChatClient.Connect(b.joinBotConfigChannels)
 
 	if strings.HasPrefix(b.cfg.Username, "justinfan") {
@@ -89,6 +142,9 @@ func (b *Bot) joinBotConfigChannels() {
 	botConfigs := b.db.GetAllJoinBotConfigs()
 	userIDs := []string{}
 	for _, botConfig := range botConfigs {
+		if botConfig.JoinFailure != "" {
+			continue
+		}
 		userIDs = append(userIDs, botConfig.OwnerTwitchID)
 	}
 
@@ -97,9 +153,39 @@ func (b *Bot) joinBotConfigChannels() {
 		log.Error(err)
 	}
 
-	b.ChatClient.WaitForConnect()
 	log.Infof("joining %d channels", len(users))
 	for _, user := range users {
+		b.channelIDs.Store(strings.ToLower(user.Login), user.ID)
 		b.ChatClient.Join(user.Login)
 	}
 }
+
+// JoinState returns the state of the bot in the channel, empty when it's not trying to join
+func (b *Bot) JoinState(channel string) chat.JoinState {
+	state, _ := b.ChatClient.JoinState(channel)
+	return state
+}
+
+// handleJoinStateChange persists failed joins on the bot config, so we don't retry them on every restart.
+// Saving the bot config clears the failure, so successful joins are not persisted.
+func (b *Bot) handleJoinStateChange(channel string, state chat.JoinState) {
+	if !state.Failed() || channel == strings.ToLower(b.cfg.Username) {
+		return
+	}
+
+	// called by the irc reader, the update must not block it
+	go b.persistJoinFailure(channel, state)
+}
+
+func (b *Bot) persistJoinFailure(channel string, state chat.JoinState) {
+	channelID, ok := b.channelIDs.Load(channel)
+	if !ok {
+		log.Warnf("[%s] join failure not persisted, channel was not joined via its bot config", channel)
+		return
+	}
+
+	err := b.db.SetBotConfigJoinFailure(context.Background(), channelID, string(state))
+	if err != nil {
+		log.Error(err)
+	}
+}
@@ -0,0 +1,94 @@
+package commander
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gempir/gempbot/internal/store"
+)
+
+const defaultCommandPrefix = "!"
+
+type channelConfig struct {
+	prefix         string
+	customCommands map[string]store.Command
+	settings       map[string]store.CommandSetting
+	aliases        map[string]string
+	permissions    map[string]store.Permission // capability holders of the channel by user id
+}
+
+// cooldownsFor falls back to the default for each cooldown the channel didn't set
+func (c *channelConfig) cooldownsFor(command string) (global time.Duration, user time.Duration) {
+	global, user = defaultGlobalCooldown, defaultUserCooldown
+
+	setting := c.settings[command]
+	if setting.GlobalCooldown != nil {
+		global = time.Duration(*setting.GlobalCooldown) * time.Second
+	}
+	if setting.UserCooldown != nil {
+		user = time.Duration(*setting.UserCooldown) * time.Second
+	}
+
+	return global, user
+}
+
+func (c *channelConfig) isDisabled(command string) bool {
+	return c.settings[command].Disabled
+}
+
+// resolve maps an alias to its command, custom command names always win over aliases
+func (c *channelConfig) resolve(name string) string {
+	if _, ok := c.customCommands[name]; ok {
+		return name
+	}
+	if command, ok := c.aliases[name]; ok {
+		return command
+	}
+
+	return name
+}
+
+// LoadChannelConfig (re)loads everything the listener caches for a channel, call after changing it via the api
+func (l *Listener) LoadChannelConfig(channelID string) {
+	cfg := &channelConfig{
+		prefix:         defaultCommandPrefix,
+		customCommands: map[string]store.Command{},
+		settings:       map[string]store.CommandSetting{},
+		aliases:        map[string]string{},
+		permissions:    map[string]store.Permission{},
+	}
+
+	botConfig, err := l.db.GetBotConfig(channelID)
+	if err == nil && botConfig.CommandPrefix != "" {
+		cfg.prefix = botConfig.CommandPrefix
+	}
+	for _, cmd := range l.db.GetCommands(channelID) {
+		cfg.customCommands[cmd.Name] = cmd
+	}
+	for _, setting := range l.db.GetCommandSettings(channelID) {
+		cfg.settings[setting.Command] = setting
+
+		for _, alias := range strings.Split(setting.Aliases, ",") {
+			alias = strings.ToLower(strings.TrimSpace(alias))
+			if alias != "" {
+				cfg.aliases[alias] = setting.Command
+			}
+		}
+	}
+
+	for _, perm := range l.db.GetChannelPermissions(channelID) {
+		cfg.permissions[perm.TwitchID] = perm
+	}
+
+	l.channels.Store(channelID, cfg)
+}
+
+func (l *Listener) getChannelConfig(channelID string) *channelConfig {
+	cfg, ok := l.channels.Load(channelID)
+	if !ok {
+		l.LoadChannelConfig(channelID)
+		cfg, _ = l.channels.Load(channelID)
+	}
+
+	return cfg
+}
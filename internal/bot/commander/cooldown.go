@@ -0,0 +1,85 @@
+package commander
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultGlobalCooldown = 3 * time.Second
+	defaultUserCooldown   = 10 * time.Second
+
+	// only start pruning expired cooldowns once there are a few of them
+	cooldownPruneThreshold = 1000
+)
+
+type cooldowns struct {
+	mu      sync.Mutex
+	until   map[string]time.Time
+	dropped map[string]int
+	now     func() time.Time
+}
+
+func newCooldowns() *cooldowns {
+	return &cooldowns{
+		until:   map[string]time.Time{},
+		dropped: map[string]int{},
+		now:     time.Now,
+	}
+}
+
+// allow reports if the command is off cooldown and starts the cooldowns when it is
+func (c *cooldowns) allow(channelID, command, userID string, global, user time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	globalKey := channelID + ":" + command
+	userKey := globalKey + ":" + userID
+
+	if now.Before(c.until[globalKey]) || now.Before(c.until[userKey]) {
+		return false
+	}
+
+	if global > 0 {
+		c.until[globalKey] = now.Add(global)
+	}
+	if user > 0 {
+		c.until[userKey] = now.Add(user)
+	}
+	c.prune(now)
+
+	return true
+}
+
+func (c *cooldowns) drop(channelID, command string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.dropped[channelID+":"+command]++
+}
+
+// droppedCounts returns how often each channel:command was dropped because of a cooldown
+func (c *cooldowns) droppedCounts() map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts := make(map[string]int, len(c.dropped))
+	for key, count := range c.dropped {
+		counts[key] = count
+	}
+
+	return counts
+}
+
+func (c *cooldowns) prune(now time.Time) {
+	if len(c.until) < cooldownPruneThreshold {
+		return
+	}
+
+	for key, until := range c.until {
+		if !now.Before(until) {
+			delete(c.until, key)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package commander
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCooldownsBlockWithinWindow(t *testing.T) {
+	now := time.Now()
+	c := newCooldowns()
+	c.now = func() time.Time { return now }
+
+	assert.True(t, c.allow("channel", "status", "user1", 5*time.Second, 0))
+	assert.False(t, c.allow("channel", "status", "user2", 5*time.Second, 0))
+	assert.True(t, c.allow("channel", "prediction", "user2", 5*time.Second, 0), "other commands are not affected")
+	assert.True(t, c.allow("otherchannel", "status", "user2", 5*time.Second, 0), "other channels are not affected")
+
+	now = now.Add(5 * time.Second)
+	assert.True(t, c.allow("channel", "status", "user2", 5*time.Second, 0))
+}
+
+func TestUserCooldownOnlyBlocksSameUser(t *testing.T) {
+	now := time.Now()
+	c := newCooldowns()
+	c.now = func() time.Time { return now }
+
+	assert.True(t, c.allow("channel", "status", "user1", 0, 10*time.Second))
+	assert.False(t, c.allow("channel", "status", "user1", 0, 10*time.Second))
+	assert.True(t, c.allow("channel", "status", "user2", 0, 10*time.Second))
+
+	now = now.Add(11 * time.Second)
+	assert.True(t, c.allow("channel", "status", "user1", 0, 10*time.Second))
+}
+
+func TestCanCountDroppedCommands(t *testing.T) {
+	c := newCooldowns()
+	c.drop("channel", "status")
+	c.drop("channel", "status")
+	c.drop("channel", "prediction")
+
+	assert.Equal(t, map[string]int{"channel:status": 2, "channel:prediction": 1}, c.droppedCounts())
+}
+
+func TestCooldownsArePruned(t *testing.T) {
+	now := time.Now()
+	c := newCooldowns()
+	c.now = func() time.Time { return now }
+
+	for i := 0; i < cooldownPruneThreshold; i++ {
+		c.until[string(rune(i))] = now.Add(-time.Second)
+	}
+	assert.True(t, c.allow("channel", "status", "user1", time.Second, 0))
+
+	assert.Len(t, c.until, 1)
+}
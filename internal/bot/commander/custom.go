@@ -0,0 +1,41 @@
+package commander
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/messages"
+	"github.com/gempir/gempbot/internal/store"
+)
+
+func (l *Listener) handleCustomCommand(payload dto.CommandPayload, cmd store.Command) {
+	uses, err := l.db.IncrementCommandUses(cmd.ChannelTwitchID, cmd.Name)
+	if err != nil {
+		log.Errorf("failed to increment uses of %s in %s: %s", cmd.Name, cmd.ChannelTwitchID, err)
+		uses = cmd.Uses
+	}
+
+	l.chatSay(payload.Msg.Channel, renderCustomCommand(cmd.Response, payload, uses))
+}
+
+// legacyPlaceholders maps the $ placeholders of older custom commands to the {} placeholders of messages.Format
+var legacyPlaceholders = strings.NewReplacer(
+	"$user", "{user}",
+	"$channel", "{channel}",
+	"$query", "{query}",
+	"$count", "{count}",
+)
+
+// renderCustomCommand fills in the placeholders, leading / and . are stripped so {query} can't be used to run chat commands
+func renderCustomCommand(response string, payload dto.CommandPayload, uses int) string {
+	rendered := messages.Format(legacyPlaceholders.Replace(response), messages.Values{
+		"user":    payload.Msg.User.DisplayName,
+		"channel": payload.Msg.Channel,
+		"query":   payload.Query,
+		"count":   strconv.Itoa(uses),
+	})
+
+	return strings.TrimLeft(strings.TrimSpace(rendered), "/.")
+}
@@ -0,0 +1,47 @@
+package commander
+
+import (
+	"testing"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/go-twitch-irc/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanRenderCustomCommand(t *testing.T) {
+	payload := dto.CommandPayload{
+		Name:  "discord",
+		Query: "some query",
+		Msg:   twitch.PrivateMessage{Channel: "gempir", User: twitch.User{DisplayName: "Nymn"}},
+	}
+
+	tests := []struct {
+		response string
+		want     string
+	}{
+		{"join the discord $user", "join the discord Nymn"},
+		{"$channel has been used $count times", "gempir has been used 5 times"},
+		{"you said: $query", "you said: some query"},
+		{"no placeholders", "no placeholders"},
+		{"$query", "some query"},
+		{"join the discord {user}", "join the discord Nymn"},
+		{"{channel} has been used {count} times, $count with the old placeholder", "gempir has been used 5 times, 5 with the old placeholder"},
+		{"{unknown} stays", "{unknown} stays"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, renderCustomCommand(test.response, payload, 5))
+	}
+}
+
+func TestCustomCommandCanNotRunChatCommands(t *testing.T) {
+	payload := dto.CommandPayload{Query: "/ban gempir", Msg: twitch.PrivateMessage{}}
+
+	assert.Equal(t, "ban gempir", renderCustomCommand("$query", payload, 1))
+}
+
+func TestCustomCommandQueryIsNotRenderedAgain(t *testing.T) {
+	payload := dto.CommandPayload{Query: "{channel} $user", Msg: twitch.PrivateMessage{Channel: "gempir", User: twitch.User{DisplayName: "Nymn"}}}
+
+	assert.Equal(t, "you said: {channel} $user", renderCustomCommand("you said: {query}", payload, 1))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/gempir/gempbot/internal/helixclient"
 	"github.com/gempir/gempbot/internal/humanize"
 	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/messages"
 	"github.com/gempir/gempbot/internal/store"
 	"github.com/gempir/go-twitch-irc/v4"
 	"github.com/nicklaw5/helix/v2"
@@ -20,18 +21,20 @@ type Handler struct {
 	cfg         *config.Config
 	db          *store.Database
 	helixClient helixclient.Client
-	chatSay     func(channel, message string)
+	messenger   *messages.Messenger
 }
 
-func NewHandler(cfg *config.Config, helixClient helixclient.Client, db *store.Database, chatSay func(channel, message string)) *Handler {
+func NewHandler(cfg *config.Config, helixClient helixclient.Client, db *store.Database, messenger *messages.Messenger) *Handler {
 	return &Handler{
 		cfg:         cfg,
 		db:          db,
 		helixClient: helixClient,
-		chatSay:     chatSay,
+		messenger:   messenger,
 	}
 }
 
+// HandleCommand dispatches the prediction, outcome, poll and quote commands
+//
 // !prediction Will nymn win this game?;yes;no;3m --> yes;no;3m
 // !prediction Will he win                        --> yes;no;1m
 // !prediction Will he win;maybe                  --> maybe;no;1m
@@ -41,6 +44,10 @@ func (h *Handler) HandleCommand(payload dto.CommandPayload) {
 		h.setOutcomeForPrediction(payload)
 	case dto.CmdNamePrediction:
 		h.handlePrediction(payload)
+	case dto.CmdNamePoll:
+		h.handlePoll(payload)
+	case dto.CmdNameQuote:
+		h.handleQuote(payload)
 	}
 }
 
@@ -58,13 +65,15 @@ func (h *Handler) handlePrediction(payload dto.CommandPayload) {
 }
 
 func (h *Handler) lockOrCancelPrediction(payload dto.CommandPayload, status string) {
-	resp, err := h.helixClient.GetPredictions(&helix.PredictionsParams{BroadcasterID: payload.Msg.RoomID})
+	prediction, err := h.activePrediction(payload.Msg.RoomID)
 	if err != nil {
-		log.Error(err)
-		h.handleError(payload.Msg, err)
+		h.handleError(payload.Msg, errors.New("no active prediction"))
+		return
+	}
+	if status == dto.PredictionStatusLocked && prediction.Status == dto.PredictionStatusLocked {
+		h.handleError(payload.Msg, errors.New("prediction is already locked"))
 		return
 	}
-	prediction := resp.Data.Predictions[0]
 
 	token, err := h.db.GetUserAccessToken(payload.Msg.RoomID)
 	if err != nil {
@@ -72,7 +81,7 @@ func (h *Handler) lockOrCancelPrediction(payload dto.CommandPayload, status stri
 		return
 	}
 	h.helixClient.SetUserAccessToken(token.AccessToken)
-	resp, err = h.helixClient.EndPrediction(&helix.EndPredictionParams{BroadcasterID: payload.Msg.RoomID, ID: prediction.ID, Status: status})
+	resp, err := h.helixClient.EndPrediction(&helix.EndPredictionParams{BroadcasterID: payload.Msg.RoomID, ID: prediction.ID, Status: status})
 	h.helixClient.SetUserAccessToken("")
 
 	if err != nil {
@@ -88,15 +97,13 @@ func (h *Handler) lockOrCancelPrediction(payload dto.CommandPayload, status stri
 }
 
 func (h *Handler) setOutcomeForPrediction(payload dto.CommandPayload) {
-	var winningOutcome helix.Outcomes
+	var winningOutcome store.PredictionLogOutcome
 
-	resp, err := h.helixClient.GetPredictions(&helix.PredictionsParams{BroadcasterID: payload.Msg.RoomID})
+	prediction, err := h.activePrediction(payload.Msg.RoomID)
 	if err != nil {
-		log.Error(err)
-		h.handleError(payload.Msg, err)
+		h.handleError(payload.Msg, errors.New("no active prediction"))
 		return
 	}
-	prediction := resp.Data.Predictions[0]
 
 	for index, outcome := range prediction.Outcomes {
 		if strings.EqualFold(outcome.Title, payload.Query) || fmt.Sprintf("%d", index+1) == payload.Query {
@@ -118,6 +125,38 @@ func (h *Handler) setOutcomeForPrediction(payload dto.CommandPayload) {
 	}
 }
 
+// activePrediction asks twitch first, the stored prediction is missing when the channel doesn't subscribe to prediction events
+// and stays active when an end event was missed. The stored one is only used when twitch can't be asked.
+func (h *Handler) activePrediction(channelID string) (store.PredictionLog, error) {
+	resp, err := h.helixClient.GetPredictions(&helix.PredictionsParams{BroadcasterID: channelID, First: "1"})
+	if resp == nil || resp.StatusCode != http.StatusOK {
+		log.Warnf("failed to get predictions of %s, using the stored prediction: %v", channelID, err)
+
+		prediction, err := h.db.GetActivePrediction(channelID)
+		if err != nil {
+			return prediction, err
+		}
+		prediction.Outcomes = h.db.GetOutcomes(prediction.ID)
+
+		return prediction, nil
+	}
+	if len(resp.Data.Predictions) == 0 {
+		return store.PredictionLog{}, errors.New("no active prediction")
+	}
+
+	latest := resp.Data.Predictions[0]
+	if latest.Status != dto.PredictionStatusActive && latest.Status != dto.PredictionStatusLocked {
+		return store.PredictionLog{}, errors.New("no active prediction")
+	}
+
+	prediction := store.PredictionLog{ID: latest.ID, OwnerTwitchID: channelID, Title: latest.Title, Status: latest.Status}
+	for index, outcome := range latest.Outcomes {
+		prediction.Outcomes = append(prediction.Outcomes, store.PredictionLogOutcome{ID: outcome.ID, PredictionID: latest.ID, Position: index, Title: outcome.Title, Color: outcome.Color})
+	}
+
+	return prediction, nil
+}
+
 func (h *Handler) startPrediction(payload dto.CommandPayload) {
 	split := strings.Split(payload.Query, ";")
 
@@ -174,5 +213,5 @@ func (h *Handler) startPrediction(payload dto.CommandPayload) {
 }
 
 func (h *Handler) handleError(msg twitch.PrivateMessage, err error) {
-	h.chatSay(msg.Channel, fmt.Sprintf("@%s %s", msg.User.DisplayName, err))
+	h.messenger.Say(msg.RoomID, msg.Channel, messages.CommandError, messages.Values{"user": msg.User.DisplayName, "error": err.Error()})
 }
@@ -0,0 +1,114 @@
+package commander
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/humanize"
+	"github.com/gempir/gempbot/internal/messages"
+)
+
+const maxHelpLength = 500
+
+// Commands returns the built-in and custom commands as configured in the channel, sorted by name
+func (l *Listener) Commands(channelID string) []dto.CommandInfo {
+	cfg := l.getChannelConfig(channelID)
+
+	aliases := map[string][]string{}
+	for alias, command := range cfg.aliases {
+		aliases[command] = append(aliases[command], alias)
+	}
+
+	commands := []dto.CommandInfo{}
+	for _, cmd := range l.commands {
+		commands = append(commands, dto.CommandInfo{
+			Command: cmd,
+			Prefix:  cfg.prefix,
+			Aliases: sortedOrEmpty(aliases[cmd.Name]),
+			Enabled: !cfg.isDisabled(cmd.Name),
+		})
+	}
+	for _, custom := range cfg.customCommands {
+		commands = append(commands, dto.CommandInfo{
+			Command: dto.Command{Name: custom.Name, Description: "Custom command", Usage: custom.Name},
+			Prefix:  cfg.prefix,
+			Aliases: sortedOrEmpty(aliases[custom.Name]),
+			Enabled: !cfg.isDisabled(custom.Name),
+			Custom:  true,
+		})
+	}
+
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].Name < commands[j].Name
+	})
+
+	return commands
+}
+
+func (l *Listener) handleHelp(payload dto.CommandPayload) {
+	usable := []dto.CommandInfo{}
+	for _, cmd := range l.Commands(payload.Msg.RoomID) {
+		if cmd.Enabled && l.canUse(payload, cmd.Capability) {
+			usable = append(usable, cmd)
+		}
+	}
+
+	query := strings.ToLower(strings.TrimSpace(payload.Query))
+	if payload.Name == dto.CmdNameCommands || query == "" {
+		l.sayHelp(payload, messages.HelpCommands, messages.Values{"user": payload.Msg.User.DisplayName, "commands": listCommands(usable)})
+		return
+	}
+
+	cfg := l.getChannelConfig(payload.Msg.RoomID)
+	name := strings.TrimPrefix(query, cfg.prefix)
+	if !l.HasCommand(name) {
+		name = cfg.resolve(name)
+	}
+
+	for _, cmd := range usable {
+		if cmd.Name == name {
+			l.sayHelp(payload, messages.HelpDescribe, messages.Values{"user": payload.Msg.User.DisplayName, "command": cmd.Name, "description": describeCommand(cmd)})
+			return
+		}
+	}
+
+	l.sayHelp(payload, messages.HelpUnknown, messages.Values{"user": payload.Msg.User.DisplayName, "command": query})
+}
+
+func (l *Listener) sayHelp(payload dto.CommandPayload, key messages.Key, values messages.Values) {
+	message, ok := l.messenger.Render(payload.Msg.RoomID, key, values)
+	if !ok {
+		return
+	}
+
+	l.chatSay(payload.Msg.Channel, humanize.CharLimiter(message, maxHelpLength))
+}
+
+func listCommands(commands []dto.CommandInfo) string {
+	names := []string{}
+	for _, cmd := range commands {
+		names = append(names, cmd.Prefix+cmd.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func describeCommand(cmd dto.CommandInfo) string {
+	description := fmt.Sprintf("%s%s - %s", cmd.Prefix, cmd.Usage, cmd.Description)
+	if len(cmd.Aliases) > 0 {
+		description += fmt.Sprintf(" (aliases: %s%s)", cmd.Prefix, strings.Join(cmd.Aliases, ", "+cmd.Prefix))
+	}
+
+	return description
+}
+
+func sortedOrEmpty(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	sort.Strings(values)
+
+	return values
+}
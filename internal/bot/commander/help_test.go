@@ -0,0 +1,31 @@
+package commander
+
+import (
+	"testing"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeCommand(t *testing.T) {
+	cmd := dto.CommandInfo{
+		Command: dto.Command{Name: "sr", Description: "Adds a youtube video to the media queue", Usage: "sr <youtube link>"},
+		Prefix:  "?",
+		Aliases: []string{"song", "songrequest"},
+	}
+
+	assert.Equal(t, "?sr <youtube link> - Adds a youtube video to the media queue (aliases: ?song, ?songrequest)", describeCommand(cmd))
+
+	cmd.Aliases = []string{}
+	assert.Equal(t, "?sr <youtube link> - Adds a youtube video to the media queue", describeCommand(cmd))
+}
+
+func TestListCommands(t *testing.T) {
+	commands := []dto.CommandInfo{
+		{Command: dto.Command{Name: "help"}, Prefix: "!"},
+		{Command: dto.Command{Name: "status"}, Prefix: "!"},
+	}
+
+	assert.Equal(t, "!help, !status", listCommands(commands))
+	assert.Equal(t, "", listCommands([]dto.CommandInfo{}))
+}
@@ -1,82 +1,201 @@
 package commander
 
 import (
-	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gempir/gempbot/internal/chat/tmi"
 	"github.com/gempir/gempbot/internal/dto"
 	"github.com/gempir/gempbot/internal/humanize"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/messages"
 	"github.com/gempir/gempbot/internal/store"
 	"github.com/gempir/go-twitch-irc/v4"
+	"github.com/puzpuzpuz/xsync"
 )
 
 type Listener struct {
-	startTime          time.Time
-	db                 *store.Database
-	predictionsHandler *Handler
-	commands           map[string]func(dto.CommandPayload)
-	chatSay            func(channel, message string)
+	startTime time.Time
+	db        *store.Database
+	handler   *Handler
+	commands  map[string]dto.Command
+	channels  *xsync.MapOf[string, *channelConfig]
+	cooldowns *cooldowns
+	chatSay   func(channel, message string)
+	messenger *messages.Messenger
 }
 
 var (
-	commandRegex = regexp.MustCompile(`^\!(\w+)\ ?`)
+	commandRegex = regexp.MustCompile(`^(\w+)\ ?`)
 )
 
-func NewListener(db *store.Database, predictionsHandler *Handler, chatSay func(channel, message string)) *Listener {
+func NewListener(db *store.Database, handler *Handler, chatSay func(channel, message string), messenger *messages.Messenger) *Listener {
 	return &Listener{
-		startTime:          time.Now(),
-		db:                 db,
-		predictionsHandler: predictionsHandler,
-		commands:           map[string]func(dto.CommandPayload){},
-		chatSay:            chatSay,
+		startTime: time.Now(),
+		db:        db,
+		handler:   handler,
+		commands:  map[string]dto.Command{},
+		channels:  xsync.NewMapOf[*channelConfig](),
+		cooldowns: newCooldowns(),
+		chatSay:   chatSay,
+		messenger: messenger,
 	}
 }
 
-func (l *Listener) RegisterCommand(command string, handler func(dto.CommandPayload)) {
-	l.commands[command] = handler
+func (l *Listener) RegisterCommand(command dto.Command) {
+	l.commands[command.Name] = command
+}
+
+func (l *Listener) HasCommand(command string) bool {
+	_, ok := l.commands[command]
+	return ok
 }
 
 func (l *Listener) RegisterDefaultCommands() {
-	l.commands[dto.CmdNameStatus] = l.handleStatus
-	l.commands[dto.CmdNamePrediction] = l.handlePrediction
-	l.commands[dto.CmdNameOutcome] = l.handlePrediction
+	l.RegisterCommand(dto.Command{
+		Name:        dto.CmdNameStatus,
+		Description: "Shows the uptime of the bot",
+		Usage:       dto.CmdNameStatus,
+		Capability:  dto.CapabilityBotConfig,
+		Handler:     l.handleStatus,
+	})
+	l.RegisterCommand(dto.Command{
+		Name:        dto.CmdNamePrediction,
+		Description: "Starts, locks or cancels a prediction",
+		Usage:       dto.CmdNamePrediction + " <title>;<duration>;<outcome>;<outcome>... | lock | cancel",
+		Capability:  dto.CapabilityPredictions,
+		Handler:     l.handler.HandleCommand,
+	})
+	l.RegisterCommand(dto.Command{
+		Name:        dto.CmdNameOutcome,
+		Description: "Resolves the running prediction",
+		Usage:       dto.CmdNameOutcome + " <number or title>",
+		Capability:  dto.CapabilityPredictions,
+		Handler:     l.handler.HandleCommand,
+	})
+	l.RegisterCommand(dto.Command{
+		Name:        dto.CmdNamePoll,
+		Description: "Starts, ends or archives a poll",
+		Usage:       dto.CmdNamePoll + " <title>;<duration>;<choice>;<choice>... | end | archive",
+		Capability:  dto.CapabilityPredictions,
+		Handler:     l.handler.HandleCommand,
+	})
+	l.RegisterCommand(dto.Command{
+		Name:        dto.CmdNameQuote,
+		Description: "Shows a random or specific quote, adding and deleting needs the commands permission",
+		Usage:       dto.CmdNameQuote + " [number] | add <text> | del <number>",
+		Handler:     l.handler.HandleCommand,
+	})
+	l.RegisterCommand(dto.Command{
+		Name:        dto.CmdNameHelp,
+		Description: "Lists the commands you can use or explains one",
+		Usage:       dto.CmdNameHelp + " [command]",
+		Handler:     l.handleHelp,
+	})
+	l.RegisterCommand(dto.Command{
+		Name:        dto.CmdNameCommands,
+		Description: "Lists the commands you can use",
+		Usage:       dto.CmdNameCommands,
+		Handler:     l.handleHelp,
+	})
 }
 
 func (l *Listener) HandlePrivateMessage(msg twitch.PrivateMessage) {
-	if !strings.HasPrefix(msg.Message, "!") {
+	cfg := l.getChannelConfig(msg.RoomID)
+
+	name, query, ok := parseCommand(msg.Message, cfg.prefix)
+	if !ok {
+		return
+	}
+	if !l.HasCommand(name) {
+		name = cfg.resolve(name)
+	}
+	if cfg.isDisabled(name) {
 		return
 	}
 
-	match := commandRegex.FindStringSubmatch(msg.Message)
-	if len(match) < 2 {
+	payload := dto.CommandPayload{Msg: msg, Name: name, Query: query, Prefix: cfg.prefix}
+
+	if cmd, ok := l.commands[name]; ok {
+		if !l.canUse(payload, cmd.Capability) {
+			return
+		}
+		if l.passesCooldown(payload) {
+			cmd.Handler(payload)
+		}
 		return
 	}
 
-	if cmd, ok := l.commands[match[1]]; ok {
-		cmd(dto.CommandPayload{Msg: msg, Name: match[1], Query: strings.TrimSpace(strings.TrimPrefix(msg.Message, "!"+match[1]))})
+	if custom, ok := cfg.customCommands[name]; ok {
+		if l.passesCooldown(payload) {
+			l.handleCustomCommand(payload, custom)
+		}
+	}
+}
+
+// parseCommand splits "<prefix><name> <query>" into the lowercased name and the query
+func parseCommand(message, prefix string) (name string, query string, ok bool) {
+	if !strings.HasPrefix(message, prefix) {
+		return "", "", false
+	}
+
+	match := commandRegex.FindStringSubmatch(strings.TrimPrefix(message, prefix))
+	if len(match) < 2 {
+		return "", "", false
 	}
+
+	return strings.ToLower(match[1]), strings.TrimSpace(strings.TrimPrefix(message, prefix+match[1])), true
 }
 
-func (l *Listener) hasElevatedPermissions(payload dto.CommandPayload) bool {
-	return tmi.IsModerator(payload.Msg.User) || tmi.IsBroadcaster(payload.Msg.User) || l.db.GetChannelUserPermissions(payload.Msg.User.ID, payload.Msg.RoomID).Prediction
+// DroppedCommands returns how many calls per channel:command were dropped because of cooldowns
+func (l *Listener) DroppedCommands() map[string]int {
+	return l.cooldowns.droppedCounts()
 }
 
-func (l *Listener) handlePrediction(payload dto.CommandPayload) {
-	if !l.hasElevatedPermissions(payload) {
-		return
+func (l *Listener) passesCooldown(payload dto.CommandPayload) bool {
+	global, user := l.getChannelConfig(payload.Msg.RoomID).cooldownsFor(payload.Name)
+	if l.cooldowns.allow(payload.Msg.RoomID, payload.Name, payload.Msg.User.ID, global, user) {
+		return true
+	}
+	if l.isModOrBroadcaster(payload) || l.userPermissions(payload).HasAny() {
+		return true
 	}
 
-	l.predictionsHandler.HandleCommand(payload)
+	l.cooldowns.drop(payload.Msg.RoomID, payload.Name)
+	log.Debugf("dropped %s in %s by %s, on cooldown", payload.Name, payload.Msg.Channel, payload.Msg.User.Name)
+	return false
+}
+
+func (l *Listener) isModOrBroadcaster(payload dto.CommandPayload) bool {
+	return tmi.IsModerator(payload.Msg.User) || tmi.IsBroadcaster(payload.Msg.User)
+}
+
+// canUse checks the required capability of a command, 0 allows everyone.
+// Permissions are only looked up when the capability decides.
+func (l *Listener) canUse(payload dto.CommandPayload, capability int64) bool {
+	if capability == 0 || l.isModOrBroadcaster(payload) {
+		return true
+	}
+
+	return l.userPermissions(payload).Has(capability)
+}
+
+func (l *Listener) userPermissions(payload dto.CommandPayload) store.Permission {
+	return l.getChannelConfig(payload.Msg.RoomID).permissions[payload.Msg.User.ID]
 }
 
 func (l *Listener) handleStatus(payload dto.CommandPayload) {
-	if !l.hasElevatedPermissions(payload) {
-		return
+	dropped := 0
+	for _, count := range l.cooldowns.droppedCounts() {
+		dropped += count
 	}
 
 	uptime := humanize.TimeSince(l.startTime)
-	l.chatSay(payload.Msg.Channel, fmt.Sprintf("@%s, uptime: %s", payload.Msg.User.DisplayName, uptime))
+	l.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.CommandStatus, messages.Values{
+		"user":    payload.Msg.User.DisplayName,
+		"uptime":  uptime,
+		"dropped": strconv.Itoa(dropped),
+	})
 }
@@ -0,0 +1,64 @@
+package commander
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommand(t *testing.T) {
+	name, query, ok := parseCommand("!prediction Will he win?;yes;no", "!")
+	assert.True(t, ok)
+	assert.Equal(t, "prediction", name)
+	assert.Equal(t, "Will he win?;yes;no", query)
+
+	name, query, ok = parseCommand("?SR https://youtu.be/dQw4w9WgXcQ", "?")
+	assert.True(t, ok)
+	assert.Equal(t, "sr", name)
+	assert.Equal(t, "https://youtu.be/dQw4w9WgXcQ", query)
+
+	name, _, ok = parseCommand("~>status", "~>")
+	assert.True(t, ok)
+	assert.Equal(t, "status", name)
+
+	_, _, ok = parseCommand("!status", "?")
+	assert.False(t, ok)
+
+	_, _, ok = parseCommand("! status", "!")
+	assert.False(t, ok)
+}
+
+func TestChannelConfigResolve(t *testing.T) {
+	cfg := &channelConfig{
+		customCommands: map[string]store.Command{"pred": {Name: "pred"}},
+		aliases:        map[string]string{"pred": "prediction", "songrequest": "sr"},
+	}
+
+	assert.Equal(t, "sr", cfg.resolve("songrequest"))
+	assert.Equal(t, "pred", cfg.resolve("pred"), "custom command should win over alias")
+	assert.Equal(t, "unknown", cfg.resolve("unknown"))
+}
+
+func TestChannelConfigCooldownsFallBackToDefaults(t *testing.T) {
+	zero := 0
+	cfg := &channelConfig{
+		settings: map[string]store.CommandSetting{
+			"prediction": {Command: "prediction", Aliases: "pred"},
+			"status":     {Command: "status", GlobalCooldown: &zero},
+		},
+	}
+
+	global, user := cfg.cooldownsFor("prediction")
+	assert.Equal(t, defaultGlobalCooldown, global, "saving only an alias keeps the default cooldowns")
+	assert.Equal(t, defaultUserCooldown, user)
+
+	global, user = cfg.cooldownsFor("status")
+	assert.Equal(t, time.Duration(0), global)
+	assert.Equal(t, defaultUserCooldown, user)
+
+	global, user = cfg.cooldownsFor("unknown")
+	assert.Equal(t, defaultGlobalCooldown, global)
+	assert.Equal(t, defaultUserCooldown, user)
+}
@@ -0,0 +1,118 @@
+package commander
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/humanize"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/nicklaw5/helix/v2"
+)
+
+const (
+	minPollDuration = 15
+	maxPollDuration = 1800
+	maxPollChoices  = 5
+)
+
+// !poll Best game?;2m;Minecraft;Factorio --> Minecraft;Factorio;2m
+// !poll Is he winning                     --> yes;no;1m
+// !poll end                               --> ends the poll, result stays visible
+// !poll archive                           --> ends the poll and hides it
+func (h *Handler) handlePoll(payload dto.CommandPayload) {
+	if strings.ToLower(payload.Query) == "end" {
+		h.endPoll(payload, dto.PollStatusTerminated)
+		return
+	}
+	if strings.ToLower(payload.Query) == "archive" {
+		h.endPoll(payload, dto.PollStatusArchived)
+		return
+	}
+
+	h.startPoll(payload)
+}
+
+func (h *Handler) endPoll(payload dto.CommandPayload, status string) {
+	resp, err := h.helixClient.GetPolls(&helix.PollsParams{BroadcasterID: payload.Msg.RoomID, First: "1"})
+	if err != nil {
+		log.Error(err)
+		h.handleError(payload.Msg, err)
+		return
+	}
+	poll := resp.Data.Polls[0]
+	if poll.Status != dto.PollStatusActive {
+		h.handleError(payload.Msg, errors.New("no active poll"))
+		return
+	}
+
+	_, err = h.helixClient.EndPoll(&helix.EndPollParams{BroadcasterID: payload.Msg.RoomID, ID: poll.ID, Status: status})
+	if err != nil {
+		log.Error(err)
+		h.handleError(payload.Msg, err)
+		return
+	}
+}
+
+func (h *Handler) startPoll(payload dto.CommandPayload) {
+	split := strings.Split(payload.Query, ";")
+
+	title := strings.TrimSpace(split[0])
+	if title == "" {
+		h.handleError(payload.Msg, errors.New("no title given"))
+		return
+	}
+
+	duration := 60
+	if len(split) >= 2 {
+		var err error
+		duration, err = humanize.StringToSeconds(strings.TrimSpace(split[1]))
+		if err != nil {
+			log.Error(err)
+			h.handleError(payload.Msg, errors.New("failed to parse time"))
+			return
+		}
+	}
+	if duration < minPollDuration || duration > maxPollDuration {
+		h.handleError(payload.Msg, errors.New("poll duration must be between 15s and 30m"))
+		return
+	}
+
+	choices := []helix.PollChoiceParam{}
+	if len(split) >= 3 {
+		for _, choice := range split[2:] {
+			if strings.TrimSpace(choice) == "" {
+				continue
+			}
+			choices = append(choices, helix.PollChoiceParam{
+				Title: strings.TrimSpace(choice),
+			})
+		}
+	}
+	if len(choices) == 0 {
+		choices = append(choices, helix.PollChoiceParam{
+			Title: "yes",
+		})
+	}
+	if len(choices) == 1 {
+		choices = append(choices, helix.PollChoiceParam{
+			Title: "no",
+		})
+	}
+	if len(choices) > maxPollChoices {
+		h.handleError(payload.Msg, errors.New("polls can have at most 5 choices"))
+		return
+	}
+
+	_, err := h.helixClient.CreatePoll(&helix.CreatePollParams{
+		BroadcasterID: payload.Msg.RoomID,
+		Title:         title,
+		Choices:       choices,
+		Duration:      duration,
+	})
+	if err != nil {
+		log.Error(err)
+		h.handleError(payload.Msg, err)
+		return
+	}
+}
@@ -0,0 +1,123 @@
+package commander
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gempir/gempbot/internal/chat/tmi"
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/messages"
+	"github.com/gempir/gempbot/internal/store"
+)
+
+// !quote            --> random quote
+// !quote 12         --> quote #12
+// !quote add <text> --> adds a quote with the current category
+// !quote del 12     --> deletes quote #12
+func (h *Handler) handleQuote(payload dto.CommandPayload) {
+	action, arg, _ := strings.Cut(payload.Query, " ")
+	arg = strings.TrimSpace(arg)
+
+	switch strings.ToLower(action) {
+	case "add":
+		if h.canManageQuotes(payload) {
+			h.addQuote(payload, arg)
+		}
+	case "del", "delete", "remove":
+		if h.canManageQuotes(payload) {
+			h.deleteQuote(payload, arg)
+		}
+	case "":
+		quote, err := h.db.GetRandomQuote(payload.Msg.RoomID)
+		if err != nil {
+			h.handleError(payload.Msg, errors.New("no quotes yet"))
+			return
+		}
+		h.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.QuoteShow, quoteValues(quote))
+	default:
+		number, err := strconv.Atoi(strings.TrimPrefix(action, "#"))
+		if err != nil {
+			h.handleError(payload.Msg, errors.New("usage: "+dto.CmdNameQuote+" [number] | add <text> | del <number>"))
+			return
+		}
+
+		quote, err := h.db.GetQuote(payload.Msg.RoomID, number)
+		if err != nil {
+			h.handleError(payload.Msg, fmt.Errorf("quote #%d not found", number))
+			return
+		}
+		h.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.QuoteShow, quoteValues(quote))
+	}
+}
+
+func (h *Handler) canManageQuotes(payload dto.CommandPayload) bool {
+	return tmi.IsModerator(payload.Msg.User) || tmi.IsBroadcaster(payload.Msg.User) ||
+		h.db.GetChannelUserPermissions(payload.Msg.User.ID, payload.Msg.RoomID).Has(dto.CapabilityCommands)
+}
+
+func (h *Handler) addQuote(payload dto.CommandPayload, text string) {
+	text = strings.TrimLeft(text, "/.")
+	if text == "" || len(text) > dto.MaxQuoteLength {
+		h.handleError(payload.Msg, fmt.Errorf("quote must be between 1 and %d characters", dto.MaxQuoteLength))
+		return
+	}
+
+	quote := store.Quote{
+		ChannelTwitchID: payload.Msg.RoomID,
+		Text:            text,
+		AuthorTwitchID:  payload.Msg.User.ID,
+		AuthorName:      payload.Msg.User.DisplayName,
+	}
+	info, err := h.helixClient.GetChannelInformation(payload.Msg.RoomID)
+	if err != nil {
+		log.Errorf("failed to get channel information %s: %s", payload.Msg.RoomID, err)
+	} else {
+		quote.Category = info.GameName
+	}
+
+	quote, err = h.db.CreateQuote(context.Background(), quote)
+	if err != nil {
+		log.Error(err)
+		h.handleError(payload.Msg, errors.New("failed to add quote"))
+		return
+	}
+
+	h.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.QuoteAdded, messages.Values{"user": payload.Msg.User.DisplayName, "number": strconv.Itoa(quote.Number)})
+}
+
+func (h *Handler) deleteQuote(payload dto.CommandPayload, arg string) {
+	number, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+	if err != nil {
+		h.handleError(payload.Msg, errors.New("usage: "+dto.CmdNameQuote+" del <number>"))
+		return
+	}
+
+	err = h.db.DeleteQuote(context.Background(), payload.Msg.RoomID, number)
+	if err != nil {
+		h.handleError(payload.Msg, fmt.Errorf("quote #%d not found", number))
+		return
+	}
+
+	h.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.QuoteDeleted, messages.Values{"user": payload.Msg.User.DisplayName, "number": strconv.Itoa(number)})
+}
+
+func quoteValues(quote store.Quote) messages.Values {
+	date := quote.CreatedAt.Format("2006-01-02")
+	details := date
+	if quote.Category != "" {
+		details = quote.Category + ", " + details
+	}
+
+	return messages.Values{
+		"number":   strconv.Itoa(quote.Number),
+		"text":     quote.Text,
+		"details":  details,
+		"category": quote.Category,
+		"date":     date,
+		"author":   quote.AuthorName,
+	}
+}
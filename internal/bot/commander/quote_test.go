@@ -0,0 +1,18 @@
+package commander
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gempir/gempbot/internal/messages"
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteValues(t *testing.T) {
+	createdAt := time.Date(2022, 12, 24, 18, 0, 0, 0, time.UTC)
+	definition, _ := messages.GetDefinition(messages.QuoteShow)
+
+	assert.Equal(t, "#3: i am never wrong [Factorio, 2022-12-24]", messages.Format(definition.Default, quoteValues(store.Quote{Number: 3, Text: "i am never wrong", Category: "Factorio", CreatedAt: createdAt})))
+	assert.Equal(t, "#4: hello [2022-12-24]", messages.Format(definition.Default, quoteValues(store.Quote{Number: 4, Text: "hello", CreatedAt: createdAt})))
+}
@@ -9,7 +9,7 @@ func NewMockbot() *Mockbot {
 	return &Mockbot{}
 }
 
-func (mb *Mockbot) RegisterCommand(command string, handler func(dto.CommandPayload)) {
+func (mb *Mockbot) RegisterCommand(command dto.Command) {
 }
 
 func (mb *Mockbot) Say(channel string, message string) {
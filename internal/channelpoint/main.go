@@ -3,6 +3,7 @@ package channelpoint
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"time"
 
@@ -121,7 +122,11 @@ type BttvReward struct {
 }
 
 type BttvAdditionalOptions struct {
-	Slots int
+	Slots           int
+	RemovalStrategy dto.EmoteRemovalStrategy
+	// ExpiresAfter removes redeemed emotes after this many seconds, 0 keeps them until they are replaced
+	ExpiresAfter int
+	Rules        dto.EmoteRules
 }
 
 func (r *BttvReward) GetType() dto.RewardType {
@@ -146,7 +151,11 @@ type SevenTvReward struct {
 }
 
 type SevenTvAdditionalOptions struct {
-	Slots int
+	Slots           int
+	RemovalStrategy dto.EmoteRemovalStrategy
+	// ExpiresAfter removes redeemed emotes after this many seconds, 0 keeps them until they are replaced
+	ExpiresAfter int
+	Rules        dto.EmoteRules
 }
 
 func (r *SevenTvReward) GetType() dto.RewardType {
@@ -277,6 +286,15 @@ func CreateRewardFromBody(body io.ReadCloser) (Reward, error) {
 		if addOpts.AdditionalOptionsParsed.Slots < 1 {
 			addOpts.AdditionalOptionsParsed.Slots = 1
 		}
+		if !addOpts.AdditionalOptionsParsed.RemovalStrategy.Valid() {
+			return nil, fmt.Errorf("unknown removal strategy %s", addOpts.AdditionalOptionsParsed.RemovalStrategy)
+		}
+		if addOpts.AdditionalOptionsParsed.ExpiresAfter < 0 {
+			return nil, errors.New("expiry can't be negative")
+		}
+		if err := addOpts.AdditionalOptionsParsed.Rules.Validate(); err != nil {
+			return nil, err
+		}
 
 		return &BttvReward{
 			TwitchRewardConfig:    rewardConfig,
@@ -291,6 +309,15 @@ func CreateRewardFromBody(body io.ReadCloser) (Reward, error) {
 		if addOpts.AdditionalOptionsParsed.Slots < 1 {
 			addOpts.AdditionalOptionsParsed.Slots = 1
 		}
+		if !addOpts.AdditionalOptionsParsed.RemovalStrategy.Valid() {
+			return nil, fmt.Errorf("unknown removal strategy %s", addOpts.AdditionalOptionsParsed.RemovalStrategy)
+		}
+		if addOpts.AdditionalOptionsParsed.ExpiresAfter < 0 {
+			return nil, errors.New("expiry can't be negative")
+		}
+		if err := addOpts.AdditionalOptionsParsed.Rules.Validate(); err != nil {
+			return nil, err
+		}
 
 		return &SevenTvReward{
 			TwitchRewardConfig:       rewardConfig,
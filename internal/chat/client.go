@@ -1,9 +1,11 @@
 package chat
 
 import (
-	"time"
+	"strings"
 
+	"github.com/gempir/gempbot/internal/chat/tmi"
 	"github.com/gempir/gempbot/internal/config"
+	"github.com/gempir/gempbot/internal/dto"
 	"github.com/gempir/gempbot/internal/log"
 	"github.com/gempir/go-twitch-irc/v4"
 )
@@ -11,59 +13,128 @@ import (
 type ChatClient struct {
 	ircClient *twitch.Client
 	cfg       *config.Config
-	connected chan bool
+	queue     *queue
+	joins     *joins
+	dryRun    dryRun
+}
+
+type dryRun interface {
+	EnabledLogin(login string) bool
+	RecordLogin(login string, action string, details string)
 }
 
 func NewClient(cfg *config.Config) *ChatClient {
-	return &ChatClient{
+	c := &ChatClient{
 		cfg:       cfg,
-		connected: make(chan bool),
 		ircClient: twitch.NewClient(cfg.Username, cfg.OAuth),
+		joins:     newJoins(),
 	}
+	// Twitch allows 20 JOINs per 10 seconds, this also paces the JOINs sent after reconnecting
+	c.ircClient.SetJoinRateLimiter(twitch.CreateDefaultRateLimiter())
+	c.queue = newQueue(c.send)
+	go c.queue.run()
+
+	return c
+}
+
+// SetDryRun makes Say and Reply only log and record messages in dry-run channels
+func (c *ChatClient) SetDryRun(dryRun dryRun) {
+	c.dryRun = dryRun
 }
 
+// Say queues the message, it is sent once the rate limits of the channel allow it
 func (c *ChatClient) Say(channel string, message string) {
-	c.ircClient.Say(channel, message)
+	if c.dryRun != nil && c.dryRun.EnabledLogin(normalizeChannel(channel)) {
+		c.dryRun.RecordLogin(normalizeChannel(channel), dto.DryRunActionSay, message)
+		return
+	}
+
+	c.queue.enqueue(channel, "", message)
 }
 
 func (c *ChatClient) Reply(channel string, parentMsgId string, message string) {
-	c.ircClient.Reply(channel, parentMsgId, message)
+	if c.dryRun != nil && c.dryRun.EnabledLogin(normalizeChannel(channel)) {
+		c.dryRun.RecordLogin(normalizeChannel(channel), dto.DryRunActionReply, message)
+		return
+	}
+
+	c.queue.enqueue(channel, parentMsgId, message)
+}
+
+func (c *ChatClient) send(msg outgoingMessage) {
+	if msg.parentMsgID != "" {
+		c.ircClient.Reply(msg.channel, msg.parentMsgID, msg.message)
+		return
+	}
+
+	c.ircClient.Say(msg.channel, msg.message)
 }
 
+// Join joins the channel, JOINs are rate limited by the irc client and the state is tracked until Part
 func (c *ChatClient) Join(channel string) {
+	if !c.joins.add(channel) {
+		return
+	}
+
 	log.Infof("JOIN %s", channel)
-	c.ircClient.Join(channel)
+	c.ircClient.Join(normalizeChannel(channel))
 }
 
 func (c *ChatClient) Part(channel string) {
 	log.Infof("PART %s", channel)
-	c.ircClient.Depart(channel)
+	c.joins.remove(channel)
+	c.ircClient.Depart(normalizeChannel(channel))
 }
 
-func (c *ChatClient) WaitForConnect() {
-	select {
-	case <-c.connected:
-		log.Info("Twitch irc connection established")
-		return
-	case <-time.After(5 * time.Second):
-		log.Info("Bot connection timed out")
-		return
+// JoinState returns the state of a channel, false when we are not trying to be in it
+func (c *ChatClient) JoinState(channel string) (JoinState, bool) {
+	return c.joins.state(channel)
+}
+
+// SetOnJoinStateChange is called whenever a channel is queued, joined or failed to join
+func (c *ChatClient) SetOnJoinStateChange(f func(channel string, state JoinState)) {
+	c.joins.onChange = f
+}
+
+func (c *ChatClient) fail(channel string, state JoinState) {
+	if c.joins.set(channel, state) {
+		log.Warnf("[%s] failed to join: %s", channel, state)
+		// the irc client would join it again after reconnecting
+		c.ircClient.Depart(normalizeChannel(channel))
 	}
 }
 
 func (c *ChatClient) Connect(onConnect func()) {
 	c.ircClient.OnConnect(func() {
 		log.Info("connected to Twitch IRC")
-		go func() {
-			c.connected <- true
-		}()
+		c.joins.reconnected()
 		onConnect()
 	})
 
+	c.ircClient.OnUserStateMessage(func(userStateMessage twitch.UserStateMessage) {
+		c.queue.setModerator(userStateMessage.Channel, tmi.IsModerator(userStateMessage.User) || tmi.IsBroadcaster(userStateMessage.User))
+	})
+
 	count := 0
 	c.ircClient.OnRoomStateMessage(func(roomStateMessage twitch.RoomStateMessage) {
 		count++
 		log.Infof("%d #%s roomstate %v", count, roomStateMessage.Channel, roomStateMessage.State)
+		c.joins.set(roomStateMessage.Channel, JoinStateJoined)
+	})
+
+	c.ircClient.OnNoticeMessage(func(noticeMessage twitch.NoticeMessage) {
+		switch noticeMessage.MsgID {
+		case "msg_banned":
+			c.fail(noticeMessage.Channel, JoinStateBanned)
+		case "msg_channel_suspended", "tos_ban":
+			c.fail(noticeMessage.Channel, JoinStateSuspended)
+		}
+	})
+
+	c.ircClient.OnClearChatMessage(func(clearChatMessage twitch.ClearChatMessage) {
+		if clearChatMessage.BanDuration == 0 && strings.EqualFold(clearChatMessage.TargetUsername, c.cfg.Username) {
+			c.fail(clearChatMessage.Channel, JoinStateBanned)
+		}
 	})
 
 	err := c.ircClient.Connect()
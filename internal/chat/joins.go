@@ -0,0 +1,103 @@
+package chat
+
+import (
+	"strings"
+	"sync"
+)
+
+type JoinState string
+
+const (
+	JoinStatePending   JoinState = "pending"
+	JoinStateJoined    JoinState = "joined"
+	JoinStateBanned    JoinState = "banned"
+	JoinStateSuspended JoinState = "suspended"
+)
+
+// Failed channels are parted and not joined again until Join is called
+func (s JoinState) Failed() bool {
+	return s == JoinStateBanned || s == JoinStateSuspended
+}
+
+// joins tracks the state of every channel we want to be in, go-twitch-irc paces the JOINs
+type joins struct {
+	mu       sync.Mutex
+	states   map[string]JoinState
+	onChange func(channel string, state JoinState)
+}
+
+func newJoins() *joins {
+	return &joins{
+		states:   map[string]JoinState{},
+		onChange: func(channel string, state JoinState) {},
+	}
+}
+
+// add marks the channel as pending, false when it is already joined or waiting to be joined
+func (j *joins) add(channel string) bool {
+	channel = normalizeChannel(channel)
+
+	j.mu.Lock()
+	state, ok := j.states[channel]
+	if ok && !state.Failed() {
+		j.mu.Unlock()
+		return false
+	}
+	j.states[channel] = JoinStatePending
+	j.mu.Unlock()
+
+	j.onChange(channel, JoinStatePending)
+
+	return true
+}
+
+func (j *joins) remove(channel string) {
+	channel = normalizeChannel(channel)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	delete(j.states, channel)
+}
+
+// set updates the state of a channel we want to be in, returns false for unknown channels or unchanged states
+func (j *joins) set(channel string, state JoinState) bool {
+	channel = normalizeChannel(channel)
+
+	j.mu.Lock()
+	previous, ok := j.states[channel]
+	if !ok || previous == state {
+		j.mu.Unlock()
+		return false
+	}
+	j.states[channel] = state
+	j.mu.Unlock()
+
+	j.onChange(channel, state)
+
+	return true
+}
+
+// reconnected marks joined channels as pending, the irc client joins them again on connect and ROOMSTATE confirms it
+func (j *joins) reconnected() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for channel, state := range j.states {
+		if state == JoinStateJoined {
+			j.states[channel] = JoinStatePending
+		}
+	}
+}
+
+func (j *joins) state(channel string) (JoinState, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	state, ok := j.states[normalizeChannel(channel)]
+	return state, ok
+}
+
+func normalizeChannel(channel string) string {
+	return strings.ToLower(strings.TrimPrefix(channel, "#"))
+}
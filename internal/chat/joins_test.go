@@ -0,0 +1,49 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinsAddOnlyOnce(t *testing.T) {
+	j := newJoins()
+
+	assert.True(t, j.add("gempir"))
+	assert.False(t, j.add("#Gempir"), "pending channels are not joined again")
+
+	j.set("gempir", JoinStateJoined)
+	assert.False(t, j.add("gempir"))
+
+	j.set("gempir", JoinStateBanned)
+	assert.True(t, j.add("gempir"), "failed channels are joined again")
+}
+
+func TestJoinsState(t *testing.T) {
+	changes := []JoinState{}
+	j := newJoins()
+	j.onChange = func(channel string, state JoinState) {
+		changes = append(changes, state)
+	}
+
+	assert.False(t, j.set("gempir", JoinStateJoined), "unknown channels are ignored")
+
+	j.add("gempir")
+	assert.True(t, j.set("gempir", JoinStateJoined))
+	assert.False(t, j.set("gempir", JoinStateJoined))
+
+	j.reconnected()
+	state, _ := j.state("gempir")
+	assert.Equal(t, JoinStatePending, state)
+
+	assert.True(t, j.set("gempir", JoinStateBanned))
+	j.add("gempir")
+	state, _ = j.state("gempir")
+	assert.Equal(t, JoinStatePending, state, "joining a failed channel tries again")
+
+	j.remove("gempir")
+	_, ok := j.state("gempir")
+	assert.False(t, ok)
+
+	assert.Equal(t, []JoinState{JoinStatePending, JoinStateJoined, JoinStateBanned, JoinStatePending}, changes)
+}
@@ -0,0 +1,222 @@
+package chat
+
+import (
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gempir/gempbot/internal/log"
+)
+
+const (
+	maxMessageLength = 500
+	maxPending       = 50
+
+	rateWindow         = 30 * time.Second
+	rateLimit          = 20
+	rateLimitModerator = 100
+
+	channelPacing          = 1100 * time.Millisecond
+	channelPacingModerator = 100 * time.Millisecond
+	duplicateWindow        = 30 * time.Second
+)
+
+type outgoingMessage struct {
+	channel     string
+	parentMsgID string
+	message     string
+}
+
+type channelState struct {
+	pending     []outgoingMessage
+	moderator   bool
+	lastSent    time.Time
+	lastMessage string
+}
+
+// queue paces outgoing messages to stay within the Twitch rate limits,
+// moderators may send more messages and don't need to wait between them
+type queue struct {
+	mu       sync.Mutex
+	channels map[string]*channelState
+	// order of channels with pending messages, rotated so a busy channel doesn't starve others
+	order []string
+	sent  []time.Time
+	wake  chan struct{}
+	send  func(msg outgoingMessage)
+}
+
+func newQueue(send func(msg outgoingMessage)) *queue {
+	return &queue{
+		channels: map[string]*channelState{},
+		wake:     make(chan struct{}, 1),
+		send:     send,
+	}
+}
+
+func (q *queue) run() {
+	for {
+		msg, wait, ok := q.next(time.Now())
+		if ok {
+			q.send(msg)
+			continue
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-q.wake:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// enqueue splits long messages and drops messages already waiting in the channel
+func (q *queue) enqueue(channel string, parentMsgID string, message string) {
+	channel = normalizeChannel(channel)
+
+	q.mu.Lock()
+	state := q.getChannel(channel)
+	for _, part := range splitMessage(message, maxMessageLength) {
+		if isPending(state.pending, part) {
+			log.Debugf("[%s] collapsed duplicate message: %s", channel, part)
+			continue
+		}
+		if len(state.pending) >= maxPending {
+			log.Warnf("[%s] outgoing queue full, dropping message: %s", channel, part)
+			continue
+		}
+		if len(state.pending) == 0 {
+			q.order = append(q.order, channel)
+		}
+		state.pending = append(state.pending, outgoingMessage{channel: channel, parentMsgID: parentMsgID, message: part})
+	}
+	q.mu.Unlock()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// setModerator is called from USERSTATE, which tells us if we are a moderator after joining or sending
+func (q *queue) setModerator(channel string, moderator bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.getChannel(normalizeChannel(channel)).moderator = moderator
+}
+
+// next returns the message to send now or how long to wait for the next one
+func (q *queue) next(now time.Time) (outgoingMessage, time.Duration, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.sent) > 0 && now.Sub(q.sent[0]) >= rateWindow {
+		q.sent = q.sent[1:]
+	}
+
+	for {
+		state, wait, ok := q.pop(now)
+		if !ok {
+			return outgoingMessage{}, wait, false
+		}
+		msg := state.pending[0]
+		state.pending = state.pending[1:]
+		if len(state.pending) > 0 {
+			q.order = append(q.order, msg.channel)
+		}
+
+		// Twitch drops identical messages within 30 seconds unless we are a moderator
+		if !state.moderator && msg.message == state.lastMessage && now.Sub(state.lastSent) < duplicateWindow {
+			log.Debugf("[%s] dropped message identical to the last one: %s", msg.channel, msg.message)
+			continue
+		}
+
+		state.lastSent = now
+		state.lastMessage = msg.message
+		q.sent = append(q.sent, now)
+
+		return msg, 0, true
+	}
+}
+
+// pop removes the first channel allowed to send from the order
+func (q *queue) pop(now time.Time) (*channelState, time.Duration, bool) {
+	wait := time.Hour
+	for index, channel := range q.order {
+		state := q.channels[channel]
+
+		limit, pacing := rateLimit, channelPacing
+		if state.moderator {
+			limit, pacing = rateLimitModerator, channelPacingModerator
+		}
+
+		if len(q.sent) >= limit {
+			wait = minDuration(wait, q.sent[len(q.sent)-limit].Add(rateWindow).Sub(now))
+			continue
+		}
+		if next := state.lastSent.Add(pacing); now.Before(next) {
+			wait = minDuration(wait, next.Sub(now))
+			continue
+		}
+
+		q.order = append(q.order[:index:index], q.order[index+1:]...)
+
+		return state, 0, true
+	}
+
+	return nil, wait, false
+}
+
+func (q *queue) getChannel(channel string) *channelState {
+	state, ok := q.channels[channel]
+	if !ok {
+		state = &channelState{}
+		q.channels[channel] = state
+	}
+
+	return state
+}
+
+func isPending(pending []outgoingMessage, message string) bool {
+	for _, msg := range pending {
+		if msg.message == message {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitMessage splits at the last space before the limit, or hard at the limit when there is none
+func splitMessage(message string, limit int) []string {
+	parts := []string{}
+	for utf8.RuneCountInString(message) > limit {
+		runes := []rune(message)
+		cut := limit
+		for i := limit; i > limit/2; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+
+		parts = append(parts, strings.TrimSpace(string(runes[:cut])))
+		message = strings.TrimSpace(string(runes[cut:]))
+	}
+	if message != "" {
+		parts = append(parts, message)
+	}
+
+	return parts
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,75 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitMessage(t *testing.T) {
+	assert.Equal(t, []string{"hello"}, splitMessage("hello", 500))
+	assert.Equal(t, []string{"hello", "world"}, splitMessage("hello world", 8))
+	assert.Equal(t, []string{"abcd", "efgh", "ij"}, splitMessage("abcdefghij", 4))
+
+	parts := splitMessage(strings.Repeat("äöü ", 200), maxMessageLength)
+	assert.Len(t, parts, 2)
+	for _, part := range parts {
+		assert.LessOrEqual(t, len([]rune(part)), maxMessageLength)
+	}
+}
+
+func TestQueuePacesChannel(t *testing.T) {
+	q := newQueue(nil)
+	now := time.Now()
+
+	q.enqueue("#gempir", "", "first")
+	q.enqueue("gempir", "", "second")
+
+	msg, _, ok := q.next(now)
+	assert.True(t, ok)
+	assert.Equal(t, "first", msg.message)
+
+	_, wait, ok := q.next(now)
+	assert.False(t, ok)
+	assert.Equal(t, channelPacing, wait)
+
+	msg, _, ok = q.next(now.Add(channelPacing))
+	assert.True(t, ok)
+	assert.Equal(t, "second", msg.message)
+}
+
+func TestQueueCollapsesDuplicates(t *testing.T) {
+	q := newQueue(nil)
+	now := time.Now()
+
+	q.enqueue("gempir", "", "PogChamp")
+	q.enqueue("gempir", "", "PogChamp")
+
+	_, _, ok := q.next(now)
+	assert.True(t, ok)
+
+	q.enqueue("gempir", "", "PogChamp")
+	_, _, ok = q.next(now.Add(channelPacing))
+	assert.False(t, ok, "identical message within 30 seconds is dropped")
+}
+
+func TestQueueModeratorLimit(t *testing.T) {
+	q := newQueue(nil)
+	q.setModerator("gempir", true)
+	now := time.Now()
+
+	sent := 0
+	for i := 0; i < rateLimitModerator+1; i++ {
+		q.enqueue("gempir", "", strings.Repeat("a", i+1))
+		if _, _, ok := q.next(now.Add(time.Duration(i) * channelPacingModerator)); ok {
+			sent++
+		}
+	}
+	assert.Equal(t, rateLimitModerator, sent)
+
+	q.enqueue("nymn", "", "hello")
+	_, _, ok := q.next(now.Add(time.Duration(rateLimitModerator+1) * channelPacingModerator))
+	assert.False(t, ok, "global limit also applies to channels we don't moderate")
+}
@@ -0,0 +1,142 @@
+package chatlog
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gempir/gempbot/internal/config"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/gempir/go-twitch-irc/v4"
+	"github.com/puzpuzpuz/xsync"
+)
+
+const (
+	batchSize         = 100
+	flushInterval     = 2 * time.Second
+	bufferSize        = 10000
+	partitionInterval = time.Hour
+)
+
+type storage interface {
+	GetAllChatLogBotConfigs() []store.BotConfig
+	CreateChatMessages(ctx context.Context, messages []store.ChatMessage) error
+	EnsureChatMessagePartitions(ctx context.Context, from time.Time, to time.Time) error
+	DropChatMessagePartitions(ctx context.Context, before time.Time) (int, error)
+}
+
+// Logger persists the chat messages of opted-in channels in batches and drops them after the retention period
+type Logger struct {
+	db        storage
+	retention time.Duration
+	channels  *xsync.MapOf[string, bool]
+	messages  chan store.ChatMessage
+	now       func() time.Time
+}
+
+func NewLogger(cfg *config.Config, db storage) *Logger {
+	return &Logger{
+		db:        db,
+		retention: time.Duration(cfg.ChatLogRetentionDays) * 24 * time.Hour,
+		channels:  xsync.NewMapOf[bool](),
+		messages:  make(chan store.ChatMessage, bufferSize),
+		now:       time.Now,
+	}
+}
+
+// Start loads the opted-in channels, then writes messages and maintains the partitions
+func (l *Logger) Start() {
+	for _, botConfig := range l.db.GetAllChatLogBotConfigs() {
+		l.channels.Store(botConfig.OwnerTwitchID, true)
+	}
+
+	go l.maintainPartitions()
+
+	batch := []store.ChatMessage{}
+	ticker := time.NewTicker(flushInterval)
+	for {
+		select {
+		case msg := <-l.messages:
+			batch = append(batch, msg)
+			if len(batch) < batchSize {
+				continue
+			}
+		case <-ticker.C:
+		}
+
+		l.flush(batch)
+		batch = batch[:0]
+	}
+}
+
+// SetChannel is called when the chat log flag of a bot config changes
+func (l *Logger) SetChannel(channelID string, enabled bool) {
+	if enabled {
+		l.channels.Store(channelID, true)
+		return
+	}
+
+	l.channels.Delete(channelID)
+}
+
+func (l *Logger) HandlePrivateMessage(msg twitch.PrivateMessage) {
+	if _, ok := l.channels.Load(msg.RoomID); !ok {
+		return
+	}
+
+	createdAt := msg.Time
+	if createdAt.IsZero() {
+		createdAt = l.now()
+	}
+
+	select {
+	case l.messages <- store.ChatMessage{
+		ChannelTwitchID: msg.RoomID,
+		UserTwitchID:    msg.User.ID,
+		UserLogin:       strings.ToLower(msg.User.Name),
+		UserDisplayName: msg.User.DisplayName,
+		MessageID:       msg.ID,
+		Text:            msg.Message,
+		CreatedAt:       createdAt,
+	}:
+	default:
+		log.Warnf("[%s] chat log buffer full, dropping message %s", msg.Channel, msg.ID)
+	}
+}
+
+func (l *Logger) flush(batch []store.ChatMessage) {
+	if len(batch) == 0 {
+		return
+	}
+
+	err := l.db.CreateChatMessages(context.Background(), batch)
+	if err != nil {
+		log.Errorf("failed to log %d chat messages: %s", len(batch), err)
+	}
+}
+
+func (l *Logger) maintainPartitions() {
+	l.rotatePartitions()
+	for range time.NewTicker(partitionInterval).C {
+		l.rotatePartitions()
+	}
+}
+
+// rotatePartitions creates the partition of the next day ahead of time and drops expired ones
+func (l *Logger) rotatePartitions() {
+	now := l.now()
+
+	err := l.db.EnsureChatMessagePartitions(context.Background(), now, now.Add(24*time.Hour))
+	if err != nil {
+		log.Errorf("failed to create chat message partitions: %s", err)
+	}
+
+	dropped, err := l.db.DropChatMessagePartitions(context.Background(), now.Add(-l.retention))
+	if err != nil {
+		log.Errorf("failed to drop expired chat message partitions: %s", err)
+	}
+	if dropped > 0 {
+		log.Infof("dropped %d expired chat message partitions", dropped)
+	}
+}
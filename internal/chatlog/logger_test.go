@@ -0,0 +1,78 @@
+package chatlog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gempir/gempbot/internal/config"
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/gempir/go-twitch-irc/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockStorage struct {
+	droppedBefore time.Time
+}
+
+func (s *mockStorage) GetAllChatLogBotConfigs() []store.BotConfig {
+	return []store.BotConfig{}
+}
+
+func (s *mockStorage) CreateChatMessages(ctx context.Context, messages []store.ChatMessage) error {
+	return nil
+}
+
+func (s *mockStorage) EnsureChatMessagePartitions(ctx context.Context, from time.Time, to time.Time) error {
+	return nil
+}
+
+func (s *mockStorage) DropChatMessagePartitions(ctx context.Context, before time.Time) (int, error) {
+	s.droppedBefore = before
+	return 0, nil
+}
+
+func TestHandlePrivateMessageOnlyLogsOptedInChannels(t *testing.T) {
+	l := NewLogger(config.NewMockConfig(), &mockStorage{})
+	msg := twitch.PrivateMessage{RoomID: "77829817", ID: "abc", Message: "hello", User: twitch.User{ID: "123", Name: "Gempir", DisplayName: "Gempir"}}
+
+	l.HandlePrivateMessage(msg)
+	assert.Len(t, l.messages, 0)
+
+	l.SetChannel("77829817", true)
+	l.HandlePrivateMessage(msg)
+	assert.Len(t, l.messages, 1)
+
+	logged := <-l.messages
+	assert.Equal(t, "gempir", logged.UserLogin)
+	assert.Equal(t, "hello", logged.Text)
+	assert.False(t, logged.CreatedAt.IsZero())
+
+	l.SetChannel("77829817", false)
+	l.HandlePrivateMessage(msg)
+	assert.Len(t, l.messages, 0)
+}
+
+func TestRotatePartitionsUsesRetention(t *testing.T) {
+	cfg := config.NewMockConfig()
+	cfg.ChatLogRetentionDays = 7
+	db := &mockStorage{}
+	l := NewLogger(cfg, db)
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	l.rotatePartitions()
+	assert.Equal(t, now.AddDate(0, 0, -7), db.droppedBefore)
+}
+
+func TestPartitionName(t *testing.T) {
+	day := time.Date(2023, 5, 10, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, "chat_messages_20230510", store.ChatMessagePartitionName(day))
+
+	parsed, ok := store.ParseChatMessagePartitionName("chat_messages_20230510")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2023, 5, 10, 0, 0, 0, 0, time.UTC), parsed)
+
+	_, ok = store.ParseChatMessagePartitionName("chat_messages")
+	assert.False(t, ok)
+}
@@ -2,22 +2,25 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"strings"
 )
 
 // Config application configuratin
 type Config struct {
-	Username          string `json:"username"`
-	OAuth             string `json:"oauth"`
-	ClientID          string `json:"clientId"`
-	ClientSecret      string `json:"clientSecret"`
-	Secret            string `json:"secret"`
-	WebBaseUrl        string `json:"webBaseUrl"`
-	WebhookApiBaseUrl string `json:"webhookApiBaseUrl"`
-	CookieDomain      string `json:"cookieDomain"`
-	DSN               string `json:"DSN"`
-	LogLevel          string `json:"logLevel"`
-	ListenAddress     string `json:"listenAddress"`
+	Username             string `json:"username"`
+	OAuth                string `json:"oauth"`
+	ClientID             string `json:"clientId"`
+	ClientSecret         string `json:"clientSecret"`
+	Secret               string `json:"secret"`
+	WebBaseUrl           string `json:"webBaseUrl"`
+	WebhookApiBaseUrl    string `json:"webhookApiBaseUrl"`
+	CookieDomain         string `json:"cookieDomain"`
+	DSN                  string `json:"DSN"`
+	LogLevel             string `json:"logLevel"`
+	ListenAddress        string `json:"listenAddress"`
+	DryRun               bool   `json:"dryRun"`
+	ChatLogRetentionDays int    `json:"chatLogRetentionDays"`
 }
 
 func FromEnv() *Config {
@@ -36,18 +39,25 @@ func FromEnv() *Config {
 		logLevel = "info"
 	}
 
+	chatLogRetentionDays, err := strconv.Atoi(Getenv("CHAT_LOG_RETENTION_DAYS"))
+	if err != nil || chatLogRetentionDays < 1 {
+		chatLogRetentionDays = 30
+	}
+
 	return &Config{
-		ClientID:          Getenv("NEXT_PUBLIC_TWITCH_CLIENT_ID"),
-		ClientSecret:      Getenv("TWITCH_CLIENT_SECRET"),
-		Secret:            Getenv("SECRET"),
-		WebBaseUrl:        Getenv("NEXT_PUBLIC_BASE_URL"),
-		WebhookApiBaseUrl: webhookApiBaseUrl,
-		CookieDomain:      Getenv("COOKIE_DOMAIN"),
-		Username:          Getenv("TWITCH_USERNAME"),
-		OAuth:             Getenv("TWITCH_OAUTH"),
-		DSN:               Getenv("DSN"),
-		LogLevel:          logLevel,
-		ListenAddress:     listenAddress,
+		ClientID:             Getenv("NEXT_PUBLIC_TWITCH_CLIENT_ID"),
+		ClientSecret:         Getenv("TWITCH_CLIENT_SECRET"),
+		Secret:               Getenv("SECRET"),
+		WebBaseUrl:           Getenv("NEXT_PUBLIC_BASE_URL"),
+		WebhookApiBaseUrl:    webhookApiBaseUrl,
+		CookieDomain:         Getenv("COOKIE_DOMAIN"),
+		Username:             Getenv("TWITCH_USERNAME"),
+		OAuth:                Getenv("TWITCH_OAUTH"),
+		DSN:                  Getenv("DSN"),
+		LogLevel:             logLevel,
+		ListenAddress:        listenAddress,
+		DryRun:               Getenv("DRY_RUN") == "true",
+		ChatLogRetentionDays: chatLogRetentionDays,
 	}
 }
 
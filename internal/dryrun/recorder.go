@@ -0,0 +1,120 @@
+package dryrun
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gempir/gempbot/internal/config"
+	"github.com/gempir/gempbot/internal/helixclient"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/puzpuzpuz/xsync"
+)
+
+type storage interface {
+	GetAllDryRunBotConfigs() []store.BotConfig
+	CreateDryRunAction(ctx context.Context, action store.DryRunAction) error
+}
+
+type userResolver interface {
+	GetUserByUsername(username string) (helixclient.UserData, error)
+}
+
+// Recorder decides which channels run without side effects and records what the bot would have done instead
+type Recorder struct {
+	global   bool
+	db       storage
+	users    userResolver
+	channels *xsync.MapOf[string, bool]
+}
+
+func NewRecorder(cfg *config.Config, db storage, users userResolver) *Recorder {
+	r := &Recorder{
+		global:   cfg.DryRun,
+		db:       db,
+		users:    users,
+		channels: xsync.NewMapOf[bool](),
+	}
+	if r.global {
+		log.Warn("dry-run mode enabled, no chat messages, emote changes or predictions are sent")
+	}
+
+	for _, botConfig := range db.GetAllDryRunBotConfigs() {
+		r.channels.Store(botConfig.OwnerTwitchID, true)
+	}
+
+	return r
+}
+
+// SetChannel is called when the dry-run flag of a bot config changes
+func (r *Recorder) SetChannel(channelID string, enabled bool) {
+	if enabled {
+		r.channels.Store(channelID, true)
+		return
+	}
+
+	r.channels.Delete(channelID)
+}
+
+func (r *Recorder) Global() bool {
+	return r.global
+}
+
+func (r *Recorder) Enabled(channelID string) bool {
+	if r.global {
+		return true
+	}
+
+	_, ok := r.channels.Load(channelID)
+	return ok
+}
+
+// EnabledLogin is used by chat, which only knows channel logins.
+// It fails closed, a login that can't be resolved while any channel is in dry-run counts as dry-run.
+func (r *Recorder) EnabledLogin(login string) bool {
+	if r.global {
+		return true
+	}
+	if r.channels.Size() == 0 {
+		return false
+	}
+
+	channelID, err := r.resolve(login)
+	if err != nil {
+		log.Warnf("[dry-run] treating %s as dry-run: %s", login, err)
+		return true
+	}
+
+	return r.Enabled(channelID)
+}
+
+func (r *Recorder) Record(channelID string, action string, details string) {
+	log.Infof("[dry-run] [%s] %s %s", channelID, action, details)
+
+	err := r.db.CreateDryRunAction(context.Background(), store.DryRunAction{ChannelTwitchID: channelID, Action: action, Details: details})
+	if err != nil {
+		log.Errorf("failed to record dry-run action %s: %s", action, err)
+	}
+}
+
+func (r *Recorder) RecordLogin(login string, action string, details string) {
+	channelID, err := r.resolve(login)
+	if err != nil {
+		log.Warnf("[dry-run] [%s] %s %s, not recorded: %s", login, action, details, err)
+		return
+	}
+
+	r.Record(channelID, action, details)
+}
+
+func (r *Recorder) resolve(login string) (string, error) {
+	user, err := r.users.GetUserByUsername(login)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve channel %s: %w", login, err)
+	}
+	if user.ID == "" {
+		return "", fmt.Errorf("channel %s not found", login)
+	}
+
+	return user.ID, nil
+}
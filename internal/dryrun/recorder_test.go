@@ -0,0 +1,70 @@
+package dryrun
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gempir/gempbot/internal/config"
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/helixclient"
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingStore struct {
+	botConfigs []store.BotConfig
+	actions    []store.DryRunAction
+}
+
+func (s *recordingStore) GetAllDryRunBotConfigs() []store.BotConfig {
+	return s.botConfigs
+}
+
+func (s *recordingStore) CreateDryRunAction(ctx context.Context, action store.DryRunAction) error {
+	s.actions = append(s.actions, action)
+	return nil
+}
+
+func TestRecorderPerChannel(t *testing.T) {
+	db := &recordingStore{botConfigs: []store.BotConfig{{OwnerTwitchID: "123", DryRun: true}}}
+	r := NewRecorder(config.NewMockConfig(), db, helixclient.NewMockClient())
+
+	assert.True(t, r.Enabled("123"))
+	assert.False(t, r.Enabled("456"))
+	assert.True(t, r.EnabledLogin("testusergempir"), "mock helix client resolves every login to 123")
+
+	r.SetChannel("123", false)
+	assert.False(t, r.Enabled("123"))
+	assert.False(t, r.EnabledLogin("testusergempir"))
+
+	r.RecordLogin("testusergempir", dto.DryRunActionSay, "hello")
+	assert.Equal(t, []store.DryRunAction{{ChannelTwitchID: "123", Action: dto.DryRunActionSay, Details: "hello"}}, db.actions)
+}
+
+func TestRecorderGlobal(t *testing.T) {
+	cfg := config.NewMockConfig()
+	cfg.DryRun = true
+	r := NewRecorder(cfg, &recordingStore{}, helixclient.NewMockClient())
+
+	assert.True(t, r.Enabled("456"))
+	assert.True(t, r.EnabledLogin("nymn"))
+}
+
+type failingResolver struct {
+	helixclient.MockHelixClient
+}
+
+func (f *failingResolver) GetUserByUsername(username string) (helixclient.UserData, error) {
+	return helixclient.UserData{}, errors.New("helix is down")
+}
+
+func TestRecorderFailsClosed(t *testing.T) {
+	db := &recordingStore{botConfigs: []store.BotConfig{{OwnerTwitchID: "123", DryRun: true}}}
+	r := NewRecorder(config.NewMockConfig(), db, &failingResolver{})
+
+	assert.True(t, r.EnabledLogin("nymn"), "unresolvable logins count as dry-run while a channel is in dry-run")
+
+	r.SetChannel("123", false)
+	assert.False(t, r.EnabledLogin("nymn"), "without any dry-run channel nothing has to be resolved")
+}
@@ -5,11 +5,39 @@ import "github.com/gempir/go-twitch-irc/v4"
 type CommandPayload struct {
 	Query string
 	Name  string
-	Msg   twitch.PrivateMessage
+	// Prefix is the channel's command prefix the command was used with
+	Prefix string
+	Msg    twitch.PrivateMessage
+}
+
+// Command describes a built-in command, Usage and Name are without the channel's prefix
+type Command struct {
+	Name        string
+	Description string
+	Usage       string
+	// Capability required to use the command, 0 allows everyone. Moderators and the broadcaster can use every command.
+	Capability int64
+	Handler    func(CommandPayload) `json:"-"`
+}
+
+// CommandInfo is a command as configured in a specific channel
+type CommandInfo struct {
+	Command
+	Prefix  string
+	Aliases []string
+	Enabled bool
+	Custom  bool
 }
 
 const (
 	CmdNamePrediction = "prediction"
 	CmdNameStatus     = "status"
 	CmdNameOutcome    = "outcome"
+	CmdNamePoll       = "poll"
+	CmdNameHelp       = "help"
+	CmdNameCommands   = "commands"
+	CmdNameQuote      = "quote"
 )
+
+// MaxQuoteLength applies to quotes added in chat and in the dashboard
+const MaxQuoteLength = 400
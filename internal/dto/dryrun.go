@@ -0,0 +1,18 @@
+package dto
+
+// DryRunActions are the side effects recorded instead of executed in dry-run mode
+const (
+	DryRunActionSay                    = "say"
+	DryRunActionReply                  = "reply"
+	DryRunActionAddEmote               = "add_emote"
+	DryRunActionRemoveEmote            = "remove_emote"
+	DryRunActionUpdateRedemptionStatus = "update_redemption_status"
+	DryRunActionCreatePrediction       = "create_prediction"
+	DryRunActionEndPrediction          = "end_prediction"
+	DryRunActionCreatePoll             = "create_poll"
+	DryRunActionEndPoll                = "end_poll"
+	DryRunActionBanUser                = "ban_user"
+	DryRunActionDeleteChatMessage      = "delete_chat_message"
+	DryRunActionShoutout               = "shoutout"
+	DryRunActionAnnouncement           = "announcement"
+)
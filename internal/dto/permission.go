@@ -0,0 +1,18 @@
+package dto
+
+// Capabilities are bits in a store.Permission, combine them with utils.BitField.
+// CapabilityPredictions also covers polls.
+const (
+	CapabilityPredictions int64 = 1 << iota
+	CapabilityMedia
+	CapabilityEmotes
+	CapabilityBlocks
+	CapabilityRewards
+	CapabilityCommands
+	CapabilityBotConfig
+	CapabilityPermissions
+	CapabilityModeration
+)
+
+// CapabilityAll grants everything, it's what the old "Editor" permission is migrated to
+const CapabilityAll = CapabilityPredictions | CapabilityMedia | CapabilityEmotes | CapabilityBlocks | CapabilityRewards | CapabilityCommands | CapabilityBotConfig | CapabilityPermissions | CapabilityModeration
@@ -0,0 +1,8 @@
+package dto
+
+const (
+	PollStatusActive     = "ACTIVE"
+	PollStatusCompleted  = "COMPLETED"
+	PollStatusTerminated = "TERMINATED"
+	PollStatusArchived   = "ARCHIVED"
+)
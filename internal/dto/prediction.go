@@ -6,6 +6,7 @@ const (
 	Outcome_First_Alt  = "BLUE"
 	Outcome_Second_Alt = "PINK"
 
+	PredictionStatusActive   = "ACTIVE"
 	PredictionStatusResolved = "RESOLVED"
 	PredictionStatusCanceled = "CANCELED"
 	PredictionStatusLocked   = "LOCKED"
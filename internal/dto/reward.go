@@ -1,5 +1,11 @@
 package dto
 
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
 type RewardType string
 
 const (
@@ -14,4 +20,63 @@ const (
 	EMOTE_ADD_REMOVED_PREVIOUS EmoteChangeType = "remove"
 	EMOTE_ADD_REMOVED_RANDOM   EmoteChangeType = "removed_random"
 	EMOTE_ADD_REMOVED_BLOCKED  EmoteChangeType = "removed_blocked"
+	EMOTE_ADD_MOD_ADD          EmoteChangeType = "mod_add"
+	EMOTE_ADD_MOD_REMOVE       EmoteChangeType = "mod_remove"
+	EMOTE_ADD_REMOVED_EXPIRED  EmoteChangeType = "removed_expired"
+)
+
+// EmoteRemovalStrategy decides which emote an emote reward replaces once its slots are used up
+type EmoteRemovalStrategy string
+
+const (
+	// EMOTE_REMOVAL_FIFO removes the oldest of the last added emotes, or a random emote when the channel is full
+	EMOTE_REMOVAL_FIFO EmoteRemovalStrategy = "fifo"
+	// EMOTE_REMOVAL_LEAST_RECENTLY_USED removes the reward emote which was used in chat the longest time ago
+	EMOTE_REMOVAL_LEAST_RECENTLY_USED EmoteRemovalStrategy = "least_recently_used"
+	// EMOTE_REMOVAL_LEAST_USED removes the reward emote used in the fewest chat messages since it was added
+	EMOTE_REMOVAL_LEAST_USED EmoteRemovalStrategy = "least_used"
+	// EMOTE_REMOVAL_RANDOM removes a random reward emote, never one added elsewhere
+	EMOTE_REMOVAL_RANDOM EmoteRemovalStrategy = "random"
+	// EMOTE_REMOVAL_REFUSE rejects the redemption instead of removing anything
+	EMOTE_REMOVAL_REFUSE EmoteRemovalStrategy = "refuse"
 )
+
+// Valid is false for unknown strategies, empty means the default EMOTE_REMOVAL_FIFO
+func (s EmoteRemovalStrategy) Valid() bool {
+	switch s {
+	case "", EMOTE_REMOVAL_FIFO, EMOTE_REMOVAL_LEAST_RECENTLY_USED, EMOTE_REMOVAL_LEAST_USED, EMOTE_REMOVAL_RANDOM, EMOTE_REMOVAL_REFUSE:
+		return true
+	}
+
+	return false
+}
+
+// EmoteRules restrict which emotes a reward accepts, the zero value accepts every emote
+type EmoteRules struct {
+	DenyAnimated  bool
+	DenyZeroWidth bool
+	DenyUnlisted  bool
+	// AllowedCodePattern is a regex every emote code has to match, empty allows every code
+	AllowedCodePattern string
+	// DeniedCodePattern is a regex no emote code may match
+	DeniedCodePattern string
+	// DeniedUploaders are user IDs of the emote provider
+	DeniedUploaders []string
+	// MaxCodeLength of 0 allows codes of any length
+	MaxCodeLength int
+}
+
+// Validate returns an error for invalid code patterns or a negative code length
+func (r EmoteRules) Validate() error {
+	if _, err := regexp.Compile(r.AllowedCodePattern); err != nil {
+		return fmt.Errorf("invalid allowed code pattern: %s", err)
+	}
+	if _, err := regexp.Compile(r.DeniedCodePattern); err != nil {
+		return fmt.Errorf("invalid denied code pattern: %s", err)
+	}
+	if r.MaxCodeLength < 0 {
+		return errors.New("max code length can't be negative")
+	}
+
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/rand"
 	"net/http"
 	"regexp"
 	"time"
@@ -12,12 +11,14 @@ import (
 	"github.com/carlmjohnson/requests"
 	"github.com/gempir/gempbot/internal/channelpoint"
 	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/emoteservice"
 	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/messages"
 	"github.com/gempir/gempbot/internal/store"
 	"github.com/nicklaw5/helix/v2"
 )
 
-func (e *EmoteChief) VerifySetBttvEmote(channelUserID, emoteId, channel string, slots int) (addedEmote *bttvEmoteResponse, emoteAddType dto.EmoteChangeType, bttvUserId string, removalTargetEmoteId string, err error) {
+func (e *EmoteChief) VerifySetBttvEmote(channelUserID, emoteId, channel string, slots int, strategy dto.EmoteRemovalStrategy, rules dto.EmoteRules) (addedEmote *bttvEmoteResponse, emoteAddType dto.EmoteChangeType, bttvUserId string, removalTargetEmoteId string, err error) {
 	if e.db.IsEmoteBlocked(channelUserID, emoteId, dto.REWARD_BTTV) {
 		return nil, dto.EMOTE_ADD_ADD, "", "", errors.New("emote is blocked")
 	}
@@ -34,6 +35,11 @@ func (e *EmoteChief) VerifySetBttvEmote(channelUserID, emoteId, channel string,
 		return
 	}
 
+	err = checkEmoteRules(rules, addedEmote.emote())
+	if err != nil {
+		return nil, dto.EMOTE_ADD_ADD, "", "", err
+	}
+
 	// first figure out the bttvUserId for the channel, might cache this later on
 	var userResp bttvUserResponse
 	err = requests.
@@ -107,38 +113,35 @@ func (e *EmoteChief) VerifySetBttvEmote(channelUserID, emoteId, channel string,
 	}
 	log.Infof("current shared emotes: %d/%d", len(dashboard.Sharedemotes), sharedEmotesLimit)
 
-	emotesAdded := e.db.GetEmoteAdded(channelUserID, dto.REWARD_BTTV, slots)
-	log.Infof("total Previous emotes %d in %s", len(emotesAdded), channelUserID)
-
-	if len(emotesAdded) > 0 {
-		oldestEmote := emotesAdded[len(emotesAdded)-1]
-		if !oldestEmote.Blocked {
-			for _, sharedEmote := range dashboard.Sharedemotes {
-				if oldestEmote.EmoteID == sharedEmote.ID {
-					removalTargetEmoteId = oldestEmote.EmoteID
-					log.Infof("Found removal target %s in %s", removalTargetEmoteId, channelUserID)
-				}
-			}
-		} else {
-			log.Infof("Removal target %s is already blocked, so already removed, skipping removal", oldestEmote.EmoteID)
+	// without slots, e.g. when added by a moderator, nothing gets replaced
+	if slots < 1 {
+		if len(dashboard.Sharedemotes) >= sharedEmotesLimit {
+			err = errors.New("emotes limit reached, remove an emote first")
 		}
+		return
 	}
 
-	emoteAddType = dto.EMOTE_ADD_REMOVED_PREVIOUS
-	if removalTargetEmoteId == "" && len(dashboard.Sharedemotes) >= sharedEmotesLimit {
-		if len(dashboard.Sharedemotes) == 0 {
-			return nil, dto.EMOTE_ADD_ADD, "", "", errors.New("emotes limit reached and can't find amount of emotes added to choose random")
-		}
+	sharedEmotes := []emoteservice.Emote{}
+	for _, emote := range dashboard.Sharedemotes {
+		sharedEmotes = append(sharedEmotes, emoteservice.Emote{ID: emote.ID, Code: emote.Code})
+	}
 
-		emoteAddType = dto.EMOTE_ADD_REMOVED_RANDOM
-		log.Infof("Didn't find previous emote history of %d emotes and limit reached, choosing random in %s", slots, channelUserID)
-		removalTargetEmoteId = dashboard.Sharedemotes[rand.Intn(len(dashboard.Sharedemotes))].ID
+	removalTargetEmoteId, emoteAddType, err = newRemovalStrategy(e.db, strategy).removalTarget(removalRequest{
+		channelUserID: channelUserID,
+		rewardType:    dto.REWARD_BTTV,
+		slots:         slots,
+		current:       sharedEmotes,
+		limit:         sharedEmotesLimit,
+		protected:     e.db.GetEmoteProtections(channelUserID),
+	})
+	if err != nil {
+		return nil, dto.EMOTE_ADD_ADD, "", "", err
 	}
 
 	return
 }
 
-func (e *EmoteChief) RemoveBttvEmote(channelUserID, emoteID string) (*bttvEmoteResponse, error) {
+func (e *EmoteChief) RemoveBttvEmote(channelUserID, emoteID string, changeType dto.EmoteChangeType) (*bttvEmoteResponse, error) {
 	bttvToken := e.db.GetBttvToken(context.Background())
 
 	var userResp bttvUserResponse
@@ -153,24 +156,19 @@ func (e *EmoteChief) RemoveBttvEmote(channelUserID, emoteID string) (*bttvEmoteR
 
 	bttvUserId := userResp.ID
 
-	err = requests.
-		URL(BTTV_API).
-		Pathf("/3/emotes/%s/shared/%s", emoteID, bttvUserId).
-		Bearer(bttvToken).
-		Method(http.MethodDelete).
-		Fetch(context.Background())
+	err = e.changeBttvSharedEmote(channelUserID, bttvUserId, emoteID, bttvToken, http.MethodDelete)
 	if err != nil {
 		return nil, err
 	}
 
-	e.db.CreateEmoteAdd(channelUserID, dto.REWARD_BTTV, emoteID, dto.EMOTE_ADD_REMOVED_BLOCKED)
-	log.Infof("Blocked channelId: %s emoteId: %s", channelUserID, emoteID)
+	e.createEmoteAdd(channelUserID, dto.REWARD_BTTV, emoteID, changeType)
+	log.Infof("Removed channelId: %s emoteId: %s type: %s", channelUserID, emoteID, changeType)
 
 	return getBttvEmote(emoteID)
 }
 
-func (e *EmoteChief) SetBttvEmote(channelUserID, emoteId, channel string, slots int) (addedEmote *bttvEmoteResponse, removedEmote *bttvEmoteResponse, err error) {
-	addedEmote, emoteAddType, bttvUserId, removalTargetEmoteId, err := e.VerifySetBttvEmote(channelUserID, emoteId, channel, slots)
+func (e *EmoteChief) SetBttvEmote(channelUserID, emoteId, channel string, slots int, strategy dto.EmoteRemovalStrategy, rules dto.EmoteRules, changeType dto.EmoteChangeType) (addedEmote *bttvEmoteResponse, removedEmote *bttvEmoteResponse, err error) {
+	addedEmote, emoteAddType, bttvUserId, removalTargetEmoteId, err := e.VerifySetBttvEmote(channelUserID, emoteId, channel, slots, strategy, rules)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -179,39 +177,105 @@ func (e *EmoteChief) SetBttvEmote(channelUserID, emoteId, channel string, slots
 
 	// do we need to remove the emote?
 	if removalTargetEmoteId != "" {
-		err = requests.
-			URL(BTTV_API).
-			Pathf("/3/emotes/%s/shared/%s", removalTargetEmoteId, bttvUserId).
-			Bearer(bttvToken).
-			Method(http.MethodDelete).
-			Fetch(context.Background())
+		err = e.changeBttvSharedEmote(channelUserID, bttvUserId, removalTargetEmoteId, bttvToken, http.MethodDelete)
 		if err != nil {
 			return
 		}
 
-		e.db.CreateEmoteAdd(channelUserID, dto.REWARD_BTTV, removalTargetEmoteId, emoteAddType)
+		e.createEmoteAdd(channelUserID, dto.REWARD_BTTV, removalTargetEmoteId, emoteAddType)
 		log.Infof("Deleted channelId: %s emoteId: %s", channelUserID, removalTargetEmoteId)
 
 		removedEmote, _ = getBttvEmote(removalTargetEmoteId)
 	}
 
 	// Add new emote
-	err = requests.
-		URL(BTTV_API).
-		Pathf("/3/emotes/%s/shared/%s", emoteId, bttvUserId).
-		Bearer(bttvToken).
-		Method(http.MethodPut).
-		Fetch(context.Background())
+	err = e.changeBttvSharedEmote(channelUserID, bttvUserId, emoteId, bttvToken, http.MethodPut)
 	if err != nil {
 		return
 	}
 
 	log.Infof("Added channelId: %s emoteId: %s", channelUserID, emoteId)
-	e.db.CreateEmoteAdd(channelUserID, dto.REWARD_BTTV, emoteId, dto.EMOTE_ADD_ADD)
+	e.createEmoteAdd(channelUserID, dto.REWARD_BTTV, emoteId, changeType)
 
 	return
 }
 
+// changeBttvSharedEmote adds (PUT) or removes (DELETE) a shared emote, dry-run channels only record it
+func (e *EmoteChief) changeBttvSharedEmote(channelUserID, bttvUserID, emoteID, bttvToken, method string) error {
+	if e.dryRun != nil && e.dryRun.Enabled(channelUserID) {
+		action := dto.DryRunActionAddEmote
+		if method == http.MethodDelete {
+			action = dto.DryRunActionRemoveEmote
+		}
+		e.dryRun.Record(channelUserID, action, "bttv "+emoteID)
+		return nil
+	}
+
+	return requests.
+		URL(BTTV_API).
+		Pathf("/3/emotes/%s/shared/%s", emoteID, bttvUserID).
+		Bearer(bttvToken).
+		Method(method).
+		Fetch(context.Background())
+}
+
+// findBttvSharedEmote looks up the shared emote of a channel by its code
+func findBttvSharedEmote(channelUserID, code string) (string, error) {
+	dashboard, err := getBttvChannel(channelUserID)
+	if err != nil {
+		return "", err
+	}
+
+	for _, emote := range dashboard.Sharedemotes {
+		if emote.Code == code {
+			return emote.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("no shared bttv emote \"%s\" found", code)
+}
+
+// GetBttvEmotes returns the channel and shared emotes of a channel
+func GetBttvEmotes(channelUserID string) ([]emoteservice.Emote, error) {
+	dashboard, err := getBttvChannel(channelUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	emotes := []emoteservice.Emote{}
+	for _, emote := range dashboard.Channelemotes {
+		emotes = append(emotes, emoteservice.Emote{ID: emote.ID, Code: emote.Code})
+	}
+	for _, emote := range dashboard.Sharedemotes {
+		emotes = append(emotes, emoteservice.Emote{ID: emote.ID, Code: emote.Code})
+	}
+
+	return emotes, nil
+}
+
+func getBttvChannel(channelUserID string) (bttvDashboardResponse, error) {
+	var userResp bttvUserResponse
+	err := requests.
+		URL(BTTV_API).
+		Pathf("/3/cached/users/twitch/%s", channelUserID).
+		ToJSON(&userResp).
+		Fetch(context.Background())
+	if err != nil {
+		return bttvDashboardResponse{}, err
+	}
+
+	var dashboard bttvDashboardResponse
+	err = requests.
+		URL(BTTV_API).
+		Pathf("/3/users/%s", userResp.ID).
+		Param("limited", "false").
+		Param("personal", "false").
+		ToJSON(&dashboard).
+		Fetch(context.Background())
+
+	return dashboard, err
+}
+
 func getBttvEmote(emoteID string) (*bttvEmoteResponse, error) {
 	if emoteID == "" {
 		return nil, nil
@@ -234,17 +298,17 @@ func (ec *EmoteChief) VerifyBttvRedemption(reward store.ChannelPointReward, rede
 
 	emoteID, err := GetBttvEmoteId(redemption.UserInput)
 	if err == nil {
-		_, _, _, _, err := ec.VerifySetBttvEmote(redemption.BroadcasterUserID, emoteID, redemption.BroadcasterUserLogin, opts.Slots)
+		_, _, _, _, err := ec.VerifySetBttvEmote(redemption.BroadcasterUserID, emoteID, redemption.BroadcasterUserLogin, opts.Slots, opts.RemovalStrategy, opts.Rules)
 		if err != nil {
 			log.Warnf("Bttv error %s %s", redemption.BroadcasterUserLogin, err)
-			ec.chatClient.Say(redemption.BroadcasterUserLogin, fmt.Sprintf("⚠️ Failed to add bttv emote from @%s error: %s", redemption.UserName, err.Error()))
+			ec.sayRedemption(redemption, messages.EmoteBttvRedemptionFailed, messages.Values{"error": err.Error()})
 			return false
 		}
 
 		return true
 	}
 
-	ec.chatClient.Say(redemption.BroadcasterUserLogin, fmt.Sprintf("⚠️ Failed to add bttv emote from @%s error: %s", redemption.UserName, err.Error()))
+	ec.sayRedemption(redemption, messages.EmoteBttvRedemptionFailed, messages.Values{"error": err.Error()})
 	return false
 }
 
@@ -264,22 +328,22 @@ func (ec *EmoteChief) HandleBttvRedemption(reward store.ChannelPointReward, rede
 
 	emoteID, err := GetBttvEmoteId(redemption.UserInput)
 	if err == nil {
-		emoteAdded, emoteRemoved, err := ec.SetBttvEmote(redemption.BroadcasterUserID, emoteID, redemption.BroadcasterUserLogin, opts.Slots)
+		emoteAdded, emoteRemoved, err := ec.SetBttvEmote(redemption.BroadcasterUserID, emoteID, redemption.BroadcasterUserLogin, opts.Slots, opts.RemovalStrategy, opts.Rules, dto.EMOTE_ADD_ADD)
 		if err != nil {
 			log.Warnf("Bttv error %s %s", redemption.BroadcasterUserLogin, err)
-			ec.chatClient.Say(redemption.BroadcasterUserLogin, fmt.Sprintf("⚠️ Failed to add bttv emote from @%s error: %s", redemption.UserName, err.Error()))
+			ec.sayRedemption(redemption, messages.EmoteBttvRedemptionFailed, messages.Values{"error": err.Error()})
 		} else if emoteAdded != nil && emoteRemoved != nil {
 			success = true
-			ec.chatClient.Say(redemption.BroadcasterUserLogin, fmt.Sprintf("✅ Added new bttv emote %s redeemed by @%s removed: %s", emoteAdded.Code, redemption.UserName, emoteRemoved.Code))
+			ec.sayRedemption(redemption, messages.EmoteBttvRedeemedReplaced, messages.Values{"emote": emoteAdded.Code, "removed": emoteRemoved.Code})
 		} else if emoteAdded != nil {
 			success = true
-			ec.chatClient.Say(redemption.BroadcasterUserLogin, fmt.Sprintf("✅ Added new bttv emote %s redeemed by @%s", emoteAdded.Code, redemption.UserName))
+			ec.sayRedemption(redemption, messages.EmoteBttvRedeemed, messages.Values{"emote": emoteAdded.Code})
 		} else {
 			success = true
-			ec.chatClient.Say(redemption.BroadcasterUserLogin, fmt.Sprintf("✅ Added new bttv emote [unknown] redeemed by @%s", redemption.UserName))
+			ec.sayRedemption(redemption, messages.EmoteBttvRedeemed, messages.Values{"emote": "[unknown]"})
 		}
 	} else {
-		ec.chatClient.Say(redemption.BroadcasterUserLogin, fmt.Sprintf("⚠️ Failed to add bttv emote from @%s error: %s", redemption.UserName, err.Error()))
+		ec.sayRedemption(redemption, messages.EmoteBttvRedemptionFailed, messages.Values{"error": err.Error()})
 	}
 
 	if redemption.UserID == dto.GEMPIR_USER_ID {
@@ -342,6 +406,7 @@ type bttvEmoteResponse struct {
 	ID             string    `json:"id"`
 	Code           string    `json:"code"`
 	Imagetype      string    `json:"imageType"`
+	Animated       bool      `json:"animated"`
 	Createdat      time.Time `json:"createdAt"`
 	Updatedat      time.Time `json:"updatedAt"`
 	Global         bool      `json:"global"`
@@ -356,6 +421,16 @@ type bttvEmoteResponse struct {
 	} `json:"user"`
 }
 
+// emote has the details the reward rules check, BTTV has no zero-width or unlisted shared emotes
+func (e *bttvEmoteResponse) emote() emoteservice.Emote {
+	return emoteservice.Emote{
+		ID:       e.ID,
+		Code:     e.Code,
+		Animated: e.Animated || e.Imagetype == "gif",
+		OwnerID:  e.User.ID,
+	}
+}
+
 type dashboardsResponse []dashboardCfg
 
 type dashboardCfg struct {
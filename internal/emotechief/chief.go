@@ -1,27 +1,55 @@
 package emotechief
 
 import (
-	"github.com/gempir/gempbot/internal/chat"
 	"github.com/gempir/gempbot/internal/config"
+	"github.com/gempir/gempbot/internal/dto"
 	"github.com/gempir/gempbot/internal/emoteservice"
 	"github.com/gempir/gempbot/internal/helixclient"
+	"github.com/gempir/gempbot/internal/messages"
 	"github.com/gempir/gempbot/internal/store"
+	"github.com/nicklaw5/helix/v2"
 )
 
 type EmoteChief struct {
 	cfg           *config.Config
 	db            store.Store
 	helixClient   helixclient.Client
-	chatClient    *chat.ChatClient
+	messenger     *messages.Messenger
 	sevenTvClient emoteservice.ApiClient
+	dryRun        dryRun
 }
 
-func NewEmoteChief(cfg *config.Config, db store.Store, helixClient helixclient.Client, chatClient *chat.ChatClient, sevenTvClient emoteservice.ApiClient) *EmoteChief {
+type dryRun interface {
+	Enabled(channelID string) bool
+	Record(channelID string, action string, details string)
+}
+
+func NewEmoteChief(cfg *config.Config, db store.Store, helixClient helixclient.Client, messenger *messages.Messenger, sevenTvClient emoteservice.ApiClient) *EmoteChief {
 	return &EmoteChief{
 		cfg:           cfg,
 		db:            db,
 		helixClient:   helixClient,
-		chatClient:    chatClient,
+		messenger:     messenger,
 		sevenTvClient: sevenTvClient,
 	}
 }
+
+// SetDryRun records BTTV emote changes in dry-run channels and skips their emote history, 7TV changes are recorded by its client
+func (ec *EmoteChief) SetDryRun(dryRun dryRun) {
+	ec.dryRun = dryRun
+}
+
+// createEmoteAdd writes the emote history, dry-run channels only record the action so removal targets and expiry keep working on real changes
+func (ec *EmoteChief) createEmoteAdd(channelUserID string, rewardType dto.RewardType, emoteID string, changeType dto.EmoteChangeType) {
+	if ec.dryRun != nil && ec.dryRun.Enabled(channelUserID) {
+		return
+	}
+
+	ec.db.CreateEmoteAdd(channelUserID, rewardType, emoteID, changeType)
+}
+
+// sayRedemption sends a message about a redemption to its channel, {user} is the redeeming user
+func (ec *EmoteChief) sayRedemption(redemption helix.EventSubChannelPointsCustomRewardRedemptionEvent, key messages.Key, values messages.Values) {
+	values["user"] = redemption.UserName
+	ec.messenger.Say(redemption.BroadcasterUserID, redemption.BroadcasterUserLogin, key, values)
+}
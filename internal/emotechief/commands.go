@@ -0,0 +1,151 @@
+package emotechief
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/messages"
+)
+
+const (
+	CmdNameSevenTv = "7tv"
+	CmdNameBttv    = "bttv"
+)
+
+type commandRegistry interface {
+	RegisterCommand(command dto.Command)
+}
+
+func (ec *EmoteChief) RegisterCommands(registry commandRegistry) {
+	registry.RegisterCommand(dto.Command{
+		Name:        CmdNameSevenTv,
+		Description: "Adds or removes a 7TV emote",
+		Usage:       CmdNameSevenTv + " add <link> | remove <code>",
+		Capability:  dto.CapabilityEmotes,
+		Handler:     ec.HandleSevenTvCommand,
+	})
+	registry.RegisterCommand(dto.Command{
+		Name:        CmdNameBttv,
+		Description: "Adds or removes a shared bttv emote",
+		Usage:       CmdNameBttv + " add <link> | remove <code>",
+		Capability:  dto.CapabilityEmotes,
+		Handler:     ec.HandleBttvCommand,
+	})
+	registry.RegisterCommand(dto.Command{
+		Name:        CmdNameEmoteInfo,
+		Description: "Shows when and how an emote was added",
+		Usage:       CmdNameEmoteInfo + " <code>",
+		Handler:     ec.HandleEmoteInfoCommand,
+	})
+}
+
+// HandleSevenTvCommand handles "!7tv add <link>" and "!7tv remove <code>"
+func (ec *EmoteChief) HandleSevenTvCommand(payload dto.CommandPayload) {
+	action, arg := splitEmoteCommand(payload.Query)
+	switch action {
+	case "add":
+		emoteID, err := GetSevenTvEmoteId(arg)
+		if err != nil {
+			ec.replyError(payload, err)
+			return
+		}
+
+		added, _, err := ec.setSevenTvEmote(payload.Msg.RoomID, emoteID, payload.Msg.Channel, payload.Msg.User.Name, payload.Msg.User.ID, 0, dto.EMOTE_REMOVAL_FIFO, dto.EmoteRules{}, dto.EMOTE_ADD_MOD_ADD)
+		if err != nil {
+			log.Warnf("7TV error %s %s", payload.Msg.Channel, err)
+			ec.replyError(payload, err)
+			return
+		}
+
+		code := "[unknown]"
+		if emote, err := ec.sevenTvClient.GetEmote(added); err == nil {
+			code = emote.Code
+		}
+		ec.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.EmoteSevenTvAdded, messages.Values{"emote": code, "user": payload.Msg.User.DisplayName})
+	case "remove":
+		user, err := ec.sevenTvClient.GetUser(payload.Msg.RoomID)
+		if err != nil {
+			ec.replyError(payload, err)
+			return
+		}
+
+		emoteID := ""
+		for _, emote := range user.Emotes {
+			if emote.Code == arg {
+				emoteID = emote.ID
+			}
+		}
+		if emoteID == "" {
+			ec.replyError(payload, fmt.Errorf("no 7TV emote \"%s\" found", arg))
+			return
+		}
+
+		err = ec.sevenTvClient.RemoveEmote(payload.Msg.RoomID, emoteID)
+		if err != nil {
+			ec.replyError(payload, err)
+			return
+		}
+		ec.createEmoteAdd(payload.Msg.RoomID, dto.REWARD_SEVENTV, emoteID, dto.EMOTE_ADD_MOD_REMOVE)
+
+		ec.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.EmoteSevenTvRemoved, messages.Values{"emote": arg, "user": payload.Msg.User.DisplayName})
+	default:
+		ec.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.CommandUsage, messages.Values{"user": payload.Msg.User.DisplayName, "usage": payload.Prefix + CmdNameSevenTv + " add <link> | remove <code>"})
+	}
+}
+
+// HandleBttvCommand handles "!bttv add <link>" and "!bttv remove <code>"
+func (ec *EmoteChief) HandleBttvCommand(payload dto.CommandPayload) {
+	action, arg := splitEmoteCommand(payload.Query)
+	switch action {
+	case "add":
+		emoteID, err := GetBttvEmoteId(arg)
+		if err != nil {
+			ec.replyError(payload, err)
+			return
+		}
+
+		added, _, err := ec.SetBttvEmote(payload.Msg.RoomID, emoteID, payload.Msg.Channel, 0, dto.EMOTE_REMOVAL_FIFO, dto.EmoteRules{}, dto.EMOTE_ADD_MOD_ADD)
+		if err != nil {
+			log.Warnf("Bttv error %s %s", payload.Msg.Channel, err)
+			ec.replyError(payload, err)
+			return
+		}
+
+		code := "[unknown]"
+		if added != nil {
+			code = added.Code
+		}
+		ec.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.EmoteBttvAdded, messages.Values{"emote": code, "user": payload.Msg.User.DisplayName})
+	case "remove":
+		emoteID, err := findBttvSharedEmote(payload.Msg.RoomID, arg)
+		if err != nil {
+			ec.replyError(payload, err)
+			return
+		}
+
+		_, err = ec.RemoveBttvEmote(payload.Msg.RoomID, emoteID, dto.EMOTE_ADD_MOD_REMOVE)
+		if err != nil {
+			ec.replyError(payload, err)
+			return
+		}
+
+		ec.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.EmoteBttvRemoved, messages.Values{"emote": arg, "user": payload.Msg.User.DisplayName})
+	default:
+		ec.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.CommandUsage, messages.Values{"user": payload.Msg.User.DisplayName, "usage": payload.Prefix + CmdNameBttv + " add <link> | remove <code>"})
+	}
+}
+
+func (ec *EmoteChief) replyError(payload dto.CommandPayload, err error) {
+	ec.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.EmoteCommandError, messages.Values{"user": payload.Msg.User.DisplayName, "error": err.Error()})
+}
+
+func splitEmoteCommand(query string) (action string, arg string) {
+	split := strings.SplitN(strings.TrimSpace(query), " ", 2)
+	if len(split) < 2 {
+		return strings.ToLower(split[0]), ""
+	}
+
+	return strings.ToLower(split[0]), strings.TrimSpace(split[1])
+}
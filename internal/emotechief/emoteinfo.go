@@ -0,0 +1,144 @@
+package emotechief
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gempir/gempbot/internal/channelpoint"
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/emoteservice"
+	"github.com/gempir/gempbot/internal/humanize"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/messages"
+	"github.com/gempir/gempbot/internal/store"
+)
+
+const CmdNameEmoteInfo = "emoteinfo"
+
+// HandleEmoteInfoCommand answers "!emoteinfo <code>" with when and how the emote was added
+func (ec *EmoteChief) HandleEmoteInfoCommand(payload dto.CommandPayload) {
+	code := strings.TrimSpace(payload.Query)
+	if code == "" {
+		ec.replyError(payload, fmt.Errorf("usage: !emoteinfo <code>"))
+		return
+	}
+
+	rewardType, emoteID := ec.findEmoteByCode(payload.Msg.RoomID, code)
+	if emoteID == "" {
+		ec.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.EmoteInfoUnknown, messages.Values{"user": payload.Msg.User.DisplayName, "emote": code})
+		return
+	}
+
+	ec.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.EmoteInfo, messages.Values{
+		"user":  payload.Msg.User.DisplayName,
+		"emote": code,
+		"info":  ec.describeEmote(payload.Msg.RoomID, rewardType, emoteID, code),
+	})
+}
+
+func (ec *EmoteChief) findEmoteByCode(channelUserID, code string) (dto.RewardType, string) {
+	user, err := ec.sevenTvClient.GetUser(channelUserID)
+	if err != nil {
+		log.Warnf("failed to get 7TV user %s: %s", channelUserID, err)
+	}
+	for _, emote := range user.Emotes {
+		if emote.Code == code {
+			return dto.REWARD_SEVENTV, emote.ID
+		}
+	}
+
+	emoteID, err := findBttvSharedEmote(channelUserID, code)
+	if err == nil {
+		return dto.REWARD_BTTV, emoteID
+	}
+
+	return "", ""
+}
+
+func (ec *EmoteChief) describeEmote(channelUserID string, rewardType dto.RewardType, emoteID, code string) string {
+	provider := "7TV"
+	if rewardType == dto.REWARD_BTTV {
+		provider = "bttv"
+	}
+
+	emoteAdd, err := ec.db.GetLastEmoteAdd(channelUserID, rewardType, emoteID)
+	if err != nil {
+		return fmt.Sprintf("%s emote %s was not added by gempbot", provider, code)
+	}
+	if emoteAdd.ChangeType == dto.EMOTE_ADD_MOD_ADD {
+		return fmt.Sprintf("%s emote %s was added by a moderator %s ago", provider, code, humanize.TimeSince(emoteAdd.CreatedAt))
+	}
+
+	description := fmt.Sprintf("%s emote %s was added by a channel point redemption %s ago", provider, code, humanize.TimeSince(emoteAdd.CreatedAt))
+
+	req := removalRequest{rewardType: rewardType, current: []emoteservice.Emote{{ID: emoteID, Code: code}}, protected: ec.db.GetEmoteProtections(channelUserID)}
+	if req.isProtected(emoteID) {
+		return description + ", it is protected and never removed"
+	}
+
+	reward, err := ec.db.GetChannelPointReward(channelUserID, rewardType)
+	if err != nil {
+		return description
+	}
+
+	if expiresAfter := rewardExpiresAfter(reward); expiresAfter > 0 {
+		expiresAt := emoteAdd.CreatedAt.Add(expiresAfter)
+		if !expiresAt.After(time.Now()) {
+			return description + ", it expires shortly"
+		}
+		description += fmt.Sprintf(", it expires in %s", humanize.TimeUntil(time.Now(), expiresAt))
+	}
+
+	slots, strategy := rewardRemoval(reward)
+	switch strategy {
+	case dto.EMOTE_REMOVAL_LEAST_RECENTLY_USED:
+		return description + ", redemptions replace the least recently used emote"
+	case dto.EMOTE_REMOVAL_LEAST_USED:
+		return description + ", redemptions replace the least used emote"
+	case dto.EMOTE_REMOVAL_RANDOM:
+		return description + ", redemptions replace a random emote"
+	case dto.EMOTE_REMOVAL_REFUSE:
+		return description
+	}
+
+	remaining, ok := RedemptionsUntilRemoval(ec.db.GetEmoteAdded(channelUserID, rewardType, slots), emoteID, slots)
+	if !ok {
+		return description
+	}
+	if remaining == 0 {
+		return description + ", it will be replaced by the next redemption"
+	}
+
+	return description + fmt.Sprintf(", %d more redemptions until it will be replaced", remaining)
+}
+
+func rewardRemoval(reward store.ChannelPointReward) (int, dto.EmoteRemovalStrategy) {
+	if reward.Type == dto.REWARD_BTTV {
+		opts := channelpoint.UnmarshallBttvAdditionalOptions(reward.AdditionalOptions)
+		return opts.Slots, opts.RemovalStrategy
+	}
+
+	opts := channelpoint.UnmarshallSevenTvAdditionalOptions(reward.AdditionalOptions)
+	return opts.Slots, opts.RemovalStrategy
+}
+
+// RedemptionsUntilRemoval expects the newest adds first, like GetEmoteAdded returns them.
+// The oldest emote within the slots is the one replaced by the next redemption, this only holds for EMOTE_REMOVAL_FIFO.
+func RedemptionsUntilRemoval(added []store.EmoteAdd, emoteID string, slots int) (int, bool) {
+	for index, emoteAdd := range added {
+		if index >= slots {
+			break
+		}
+		if emoteAdd.EmoteID != emoteID {
+			continue
+		}
+		if index == len(added)-1 {
+			return 0, true
+		}
+
+		return slots - 1 - index, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,34 @@
+package emotechief_test
+
+import (
+	"testing"
+
+	"github.com/gempir/gempbot/internal/emotechief"
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedemptionsUntilRemoval(t *testing.T) {
+	added := []store.EmoteAdd{{EmoteID: "newest"}, {EmoteID: "middle"}, {EmoteID: "oldest"}}
+
+	tests := []struct {
+		emoteID   string
+		slots     int
+		remaining int
+		ok        bool
+	}{
+		{"oldest", 3, 0, true},
+		{"middle", 3, 1, true},
+		{"newest", 3, 2, true},
+		{"newest", 5, 4, true},
+		{"oldest", 5, 0, true},
+		{"oldest", 2, 0, false},
+		{"unknown", 3, 0, false},
+	}
+
+	for _, test := range tests {
+		remaining, ok := emotechief.RedemptionsUntilRemoval(added, test.emoteID, test.slots)
+		assert.Equal(t, test.ok, ok, test.emoteID)
+		assert.Equal(t, test.remaining, remaining, test.emoteID)
+	}
+}
@@ -0,0 +1,133 @@
+package emotechief
+
+import (
+	"context"
+	"time"
+
+	"github.com/gempir/gempbot/internal/channelpoint"
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/emoteservice"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/messages"
+	"github.com/gempir/gempbot/internal/store"
+)
+
+const expiryInterval = 5 * time.Minute
+
+// expiryLookback stops checking adds that expired long ago, e.g. emotes removed outside of the bot are never logged
+const expiryLookback = 7 * 24 * time.Hour
+
+// StartExpiry removes reward emotes once the ExpiresAfter of their reward passed
+func (ec *EmoteChief) StartExpiry() {
+	ticker := time.NewTicker(expiryInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ec.expireEmotes(time.Now())
+	}
+}
+
+func (ec *EmoteChief) expireEmotes(now time.Time) {
+	for _, reward := range ec.db.GetEmoteChannelPointRewards(context.Background()) {
+		expiresAfter := rewardExpiresAfter(reward)
+		if expiresAfter <= 0 {
+			continue
+		}
+
+		expiredBefore := now.Add(-expiresAfter)
+		emoteAdds := ec.db.GetExpiredEmoteAdds(context.Background(), reward.OwnerTwitchID, reward.Type, expiredBefore.Add(-expiryLookback), expiredBefore)
+		if len(emoteAdds) == 0 {
+			continue
+		}
+
+		err := ec.expireRewardEmotes(reward.OwnerTwitchID, reward.Type, emoteAdds)
+		if err != nil {
+			log.Errorf("failed to expire %s emotes in %s: %s", reward.Type, reward.OwnerTwitchID, err)
+		}
+	}
+}
+
+func rewardExpiresAfter(reward store.ChannelPointReward) time.Duration {
+	switch reward.Type {
+	case dto.REWARD_SEVENTV:
+		return time.Duration(channelpoint.UnmarshallSevenTvAdditionalOptions(reward.AdditionalOptions).ExpiresAfter) * time.Second
+	case dto.REWARD_BTTV:
+		return time.Duration(channelpoint.UnmarshallBttvAdditionalOptions(reward.AdditionalOptions).ExpiresAfter) * time.Second
+	}
+
+	return 0
+}
+
+// expireRewardEmotes removes the expired emotes that are still in the channel and announces each in chat
+func (ec *EmoteChief) expireRewardEmotes(channelUserID string, rewardType dto.RewardType, emoteAdds []store.EmoteAdd) error {
+	var current []emoteservice.Emote
+	if rewardType == dto.REWARD_SEVENTV {
+		user, err := ec.sevenTvClient.GetUser(channelUserID)
+		if err != nil {
+			return err
+		}
+		current = user.Emotes
+	} else {
+		dashboard, err := getBttvChannel(channelUserID)
+		if err != nil {
+			return err
+		}
+		for _, emote := range dashboard.Sharedemotes {
+			current = append(current, emoteservice.Emote{ID: emote.ID, Code: emote.Code})
+		}
+	}
+
+	req := removalRequest{channelUserID: channelUserID, rewardType: rewardType, current: current, protected: ec.db.GetEmoteProtections(channelUserID)}
+
+	channel := ""
+	for _, emoteAdd := range emoteAdds {
+		if !req.has(emoteAdd.EmoteID) {
+			continue
+		}
+		if req.isProtected(emoteAdd.EmoteID) {
+			log.Infof("Expired emote %s is protected in %s, skipping", emoteAdd.EmoteID, channelUserID)
+			continue
+		}
+
+		code, key, err := ec.removeExpiredEmote(channelUserID, rewardType, emoteAdd.EmoteID, req)
+		if err != nil {
+			log.Errorf("failed to remove expired emote %s in %s: %s", emoteAdd.EmoteID, channelUserID, err)
+			continue
+		}
+		log.Infof("Expired channelId: %s emoteId: %s type: %s", channelUserID, emoteAdd.EmoteID, rewardType)
+
+		if channel == "" {
+			user, err := ec.helixClient.GetUserByUserID(channelUserID)
+			if err != nil {
+				log.Error(err)
+				continue
+			}
+			channel = user.Login
+		}
+		ec.messenger.Say(channelUserID, channel, key, messages.Values{"emote": code})
+	}
+
+	return nil
+}
+
+func (ec *EmoteChief) removeExpiredEmote(channelUserID string, rewardType dto.RewardType, emoteID string, req removalRequest) (string, messages.Key, error) {
+	code := emoteID
+	for _, emote := range req.current {
+		if emote.ID == emoteID {
+			code = emote.Code
+		}
+	}
+
+	if rewardType == dto.REWARD_BTTV {
+		_, err := ec.RemoveBttvEmote(channelUserID, emoteID, dto.EMOTE_ADD_REMOVED_EXPIRED)
+		return code, messages.EmoteBttvExpired, err
+	}
+
+	err := ec.sevenTvClient.RemoveEmote(channelUserID, emoteID)
+	if err != nil {
+		return code, messages.EmoteSevenTvExpired, err
+	}
+	ec.createEmoteAdd(channelUserID, dto.REWARD_SEVENTV, emoteID, dto.EMOTE_ADD_REMOVED_EXPIRED)
+
+	return code, messages.EmoteSevenTvExpired, nil
+}
@@ -0,0 +1,73 @@
+package emotechief
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/emoteservice"
+	"github.com/gempir/gempbot/internal/helixclient"
+	"github.com/gempir/gempbot/internal/messages"
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type expiryApiClient struct {
+	emoteservice.MockApiClient
+	emotes  []emoteservice.Emote
+	removed []string
+}
+
+func (c *expiryApiClient) GetUser(channelID string) (emoteservice.User, error) {
+	return emoteservice.User{ID: channelID, Emotes: c.emotes, EmoteSlots: 100}, nil
+}
+
+func (c *expiryApiClient) RemoveEmote(channelID string, emoteID string) error {
+	c.removed = append(c.removed, emoteID)
+	return nil
+}
+
+func TestExpireEmotes(t *testing.T) {
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	added := func(emoteID string, age time.Duration) store.EmoteAdd {
+		return store.EmoteAdd{Model: gorm.Model{CreatedAt: now.Add(-age)}, ChannelTwitchID: "77829817", Type: dto.REWARD_SEVENTV, ChangeType: dto.EMOTE_ADD_ADD, EmoteID: emoteID}
+	}
+
+	db := &store.MockStore{
+		ChannelPointRewards: []store.ChannelPointReward{
+			{OwnerTwitchID: "77829817", Type: dto.REWARD_SEVENTV, AdditionalOptions: `{"Slots":3,"ExpiresAfter":86400}`},
+			{OwnerTwitchID: "77829817", Type: dto.REWARD_BTTV, AdditionalOptions: `{"Slots":3}`},
+		},
+		EmoteAdded: []store.EmoteAdd{
+			added("expired", 25*time.Hour),
+			added("fresh", time.Hour),
+			added("gone", 30*time.Hour),
+			added("protected", 30*time.Hour),
+			added("ancient", 10*24*time.Hour),
+		},
+		EmoteProtections: []store.EmoteProtection{{Type: dto.REWARD_SEVENTV, Emote: "Staple"}},
+	}
+	apiClient := &expiryApiClient{emotes: []emoteservice.Emote{
+		{ID: "expired", Code: "PepeLaugh"},
+		{ID: "fresh", Code: "catJAM"},
+		{ID: "protected", Code: "Staple"},
+		{ID: "ancient", Code: "OMEGALUL"},
+	}}
+	said := []string{}
+	messenger := messages.NewMessenger(db, func(channel, message string) {
+		said = append(said, message)
+	})
+	ec := NewEmoteChief(nil, db, helixclient.NewMockClient(), messenger, apiClient)
+
+	ec.expireEmotes(now)
+
+	assert.Equal(t, []string{"expired"}, apiClient.removed)
+	assert.Equal(t, []store.EmoteAdd{{ChannelTwitchID: "77829817", Type: dto.REWARD_SEVENTV, EmoteID: "expired", ChangeType: dto.EMOTE_ADD_REMOVED_EXPIRED}}, db.CreatedEmoteAdds)
+	assert.Equal(t, []string{"⌛ 7TV emote PepeLaugh expired and was removed"}, said)
+}
+
+func TestRewardExpiresAfter(t *testing.T) {
+	assert.Equal(t, 7*24*time.Hour, rewardExpiresAfter(store.ChannelPointReward{Type: dto.REWARD_BTTV, AdditionalOptions: `{"Slots":1,"ExpiresAfter":604800}`}))
+	assert.Equal(t, time.Duration(0), rewardExpiresAfter(store.ChannelPointReward{Type: dto.REWARD_SEVENTV, AdditionalOptions: `{}`}))
+}
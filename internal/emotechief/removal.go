@@ -0,0 +1,271 @@
+package emotechief
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/emoteservice"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/store"
+)
+
+// removalRequest describes the channel an emote reward adds an emote to
+type removalRequest struct {
+	channelUserID string
+	rewardType    dto.RewardType
+	slots         int
+	// current are the emotes a reward may replace, the 7TV emote set or the BTTV shared emotes
+	current []emoteservice.Emote
+	limit   int
+	// protected emotes of the channel, these are never removed
+	protected []store.EmoteProtection
+}
+
+var errAllProtected = errors.New("emotes limit reached and every emote that could be removed is protected, remove an emote first")
+
+func (r removalRequest) full() bool {
+	return len(r.current) >= r.limit
+}
+
+func (r removalRequest) has(emoteID string) bool {
+	for _, emote := range r.current {
+		if emote.ID == emoteID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isProtected matches the protections of the reward type by emote ID or by the code the emote has in the channel
+func (r removalRequest) isProtected(emoteID string) bool {
+	code := ""
+	for _, emote := range r.current {
+		if emote.ID == emoteID {
+			code = emote.Code
+		}
+	}
+
+	for _, protection := range r.protected {
+		if protection.Type != r.rewardType {
+			continue
+		}
+		if protection.Emote == emoteID || (code != "" && protection.Emote == code) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r removalRequest) unprotected() []emoteservice.Emote {
+	emotes := []emoteservice.Emote{}
+	for _, emote := range r.current {
+		if !r.isProtected(emote.ID) {
+			emotes = append(emotes, emote)
+		}
+	}
+
+	return emotes
+}
+
+// removalStrategy picks the emote to remove before a reward adds a new one, 7TV and BTTV share them
+type removalStrategy interface {
+	// removalTarget returns an empty emoteID when nothing has to be removed
+	removalTarget(req removalRequest) (emoteID string, changeType dto.EmoteChangeType, err error)
+}
+
+func newRemovalStrategy(db store.Store, strategy dto.EmoteRemovalStrategy) removalStrategy {
+	switch strategy {
+	case dto.EMOTE_REMOVAL_LEAST_RECENTLY_USED:
+		return &usageRemoval{db: db, score: lastUsed}
+	case dto.EMOTE_REMOVAL_LEAST_USED:
+		return &usageRemoval{db: db, score: timesUsed}
+	case dto.EMOTE_REMOVAL_RANDOM:
+		return &randomRemoval{db: db, intn: rand.Intn}
+	case dto.EMOTE_REMOVAL_REFUSE:
+		return &refuseRemoval{db: db}
+	}
+
+	return &fifoRemoval{db: db, intn: rand.Intn}
+}
+
+// fifoRemoval removes the oldest of the last slots added emotes, a random emote when none of them is left and the channel is full.
+// Protected emotes are skipped, the next oldest is removed instead.
+type fifoRemoval struct {
+	db   store.Store
+	intn func(n int) int
+}
+
+func (s *fifoRemoval) removalTarget(req removalRequest) (string, dto.EmoteChangeType, error) {
+	emotesAdded := s.db.GetEmoteAdded(req.channelUserID, req.rewardType, req.slots)
+	log.Infof("Total Previous emotes %d in %s", len(emotesAdded), req.channelUserID)
+
+	removalTargetEmoteId := ""
+	for i := len(emotesAdded) - 1; i >= 0; i-- {
+		oldestEmote := emotesAdded[i]
+		if req.isProtected(oldestEmote.EmoteID) {
+			log.Infof("Removal target %s is protected in %s, skipping", oldestEmote.EmoteID, req.channelUserID)
+			continue
+		}
+
+		if !oldestEmote.Blocked {
+			if req.has(oldestEmote.EmoteID) {
+				removalTargetEmoteId = oldestEmote.EmoteID
+				log.Infof("Found removal target %s in %s", removalTargetEmoteId, req.channelUserID)
+			}
+		} else {
+			log.Infof("Removal target %s is already blocked, so already removed, skipping removal", oldestEmote.EmoteID)
+		}
+		break
+	}
+
+	if removalTargetEmoteId == "" && req.full() {
+		if len(req.current) == 0 {
+			return "", dto.EMOTE_ADD_ADD, errors.New("emotes limit reached and can't find amount of emotes added to choose random")
+		}
+
+		unprotected := req.unprotected()
+		if len(unprotected) == 0 {
+			return "", dto.EMOTE_ADD_ADD, errAllProtected
+		}
+
+		log.Infof("Didn't find previous emote history of %d emotes and limit reached, choosing random in %s", req.slots, req.channelUserID)
+		return unprotected[s.intn(len(unprotected))].ID, dto.EMOTE_ADD_REMOVED_RANDOM, nil
+	}
+
+	return removalTargetEmoteId, dto.EMOTE_ADD_REMOVED_PREVIOUS, nil
+}
+
+// usageRemoval removes the reward emote with the lowest chat usage score
+type usageRemoval struct {
+	db    store.Store
+	score func(emoteAdd store.EmoteAdd, usages []store.EmoteUsage) int64
+}
+
+func (s *usageRemoval) removalTarget(req removalRequest) (string, dto.EmoteChangeType, error) {
+	candidates, err := replaceableEmotes(s.db, req)
+	if err != nil || len(candidates) == 0 {
+		return "", dto.EMOTE_ADD_REMOVED_PREVIOUS, err
+	}
+
+	oldest := candidates[len(candidates)-1]
+	usages := s.db.GetEmoteUsages(context.Background(), req.channelUserID, oldest.CreatedAt.AddDate(0, 0, -1))
+
+	// candidates are newest first, iterating backwards makes the oldest win on equal scores
+	target := oldest
+	lowest := s.score(oldest, usages)
+	for i := len(candidates) - 2; i >= 0; i-- {
+		if score := s.score(candidates[i], usages); score < lowest {
+			target, lowest = candidates[i], score
+		}
+	}
+
+	return target.EmoteID, dto.EMOTE_ADD_REMOVED_PREVIOUS, nil
+}
+
+// lastUsed scores by the last day the emote was used in chat, never used emotes score lowest
+func lastUsed(emoteAdd store.EmoteAdd, usages []store.EmoteUsage) int64 {
+	var last time.Time
+	for _, usage := range emoteUsagesSince(emoteAdd, usages) {
+		if usage.Day.After(last) {
+			last = usage.Day
+		}
+	}
+
+	if last.IsZero() {
+		return 0
+	}
+
+	return last.Unix()
+}
+
+// timesUsed scores by the number of chat messages using the emote since it was added
+func timesUsed(emoteAdd store.EmoteAdd, usages []store.EmoteUsage) int64 {
+	var count int64
+	for _, usage := range emoteUsagesSince(emoteAdd, usages) {
+		count += int64(usage.Count)
+	}
+
+	return count
+}
+
+func emoteUsagesSince(emoteAdd store.EmoteAdd, usages []store.EmoteUsage) []store.EmoteUsage {
+	added := emoteAdd.CreatedAt.UTC().Truncate(24 * time.Hour)
+
+	result := []store.EmoteUsage{}
+	for _, usage := range usages {
+		if usage.Type == emoteAdd.Type && usage.EmoteID == emoteAdd.EmoteID && !usage.Day.Before(added) {
+			result = append(result, usage)
+		}
+	}
+
+	return result
+}
+
+// randomRemoval removes a random reward emote
+type randomRemoval struct {
+	db   store.Store
+	intn func(n int) int
+}
+
+func (s *randomRemoval) removalTarget(req removalRequest) (string, dto.EmoteChangeType, error) {
+	candidates, err := replaceableEmotes(s.db, req)
+	if err != nil || len(candidates) == 0 {
+		return "", dto.EMOTE_ADD_REMOVED_RANDOM, err
+	}
+
+	return candidates[s.intn(len(candidates))].EmoteID, dto.EMOTE_ADD_REMOVED_RANDOM, nil
+}
+
+// refuseRemoval never removes an emote, redemptions fail once the slots are used up
+type refuseRemoval struct {
+	db store.Store
+}
+
+func (s *refuseRemoval) removalTarget(req removalRequest) (string, dto.EmoteChangeType, error) {
+	candidates, err := replaceableEmotes(s.db, req)
+	if err != nil {
+		return "", dto.EMOTE_ADD_REMOVED_PREVIOUS, err
+	}
+	if len(candidates) > 0 {
+		return "", dto.EMOTE_ADD_REMOVED_PREVIOUS, errors.New("emote slots are full, remove an emote first")
+	}
+
+	return "", dto.EMOTE_ADD_REMOVED_PREVIOUS, nil
+}
+
+// replaceableEmotes returns the unprotected emotes added by the reward that are still in the channel, newest first.
+// It is empty when there is space for another emote and an error when the channel is full without any reward emote to replace.
+func replaceableEmotes(db store.Store, req removalRequest) ([]store.EmoteAdd, error) {
+	seen := map[string]bool{}
+	skippedProtected := false
+	candidates := []store.EmoteAdd{}
+	for _, emoteAdd := range db.GetEmoteAdded(req.channelUserID, req.rewardType, req.slots) {
+		if emoteAdd.Blocked || seen[emoteAdd.EmoteID] || !req.has(emoteAdd.EmoteID) {
+			continue
+		}
+		if req.isProtected(emoteAdd.EmoteID) {
+			skippedProtected = true
+			continue
+		}
+		seen[emoteAdd.EmoteID] = true
+		candidates = append(candidates, emoteAdd)
+	}
+	log.Infof("%d of %d reward emote slots used in %s", len(candidates), req.slots, req.channelUserID)
+
+	if len(candidates) < req.slots && !req.full() {
+		return []store.EmoteAdd{}, nil
+	}
+	if len(candidates) == 0 && skippedProtected {
+		return candidates, errAllProtected
+	}
+	if len(candidates) == 0 {
+		return candidates, errors.New("emotes limit reached, remove an emote first")
+	}
+
+	return candidates, nil
+}
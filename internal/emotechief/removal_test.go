@@ -0,0 +1,218 @@
+package emotechief
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/emoteservice"
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var removalNow = time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+
+// rewardEmoteAdd was added by the reward the given days ago
+func rewardEmoteAdd(emoteID string, daysAgo int) store.EmoteAdd {
+	return store.EmoteAdd{Model: gorm.Model{CreatedAt: removalNow.AddDate(0, 0, -daysAgo)}, Type: dto.REWARD_SEVENTV, ChangeType: dto.EMOTE_ADD_ADD, EmoteID: emoteID}
+}
+
+func usage(emoteID string, daysAgo int, count int) store.EmoteUsage {
+	return store.EmoteUsage{Type: dto.REWARD_SEVENTV, EmoteID: emoteID, Day: removalNow.AddDate(0, 0, -daysAgo).Truncate(24 * time.Hour), Count: count}
+}
+
+func removalTestRequest(slots int, limit int, emoteIDs ...string) removalRequest {
+	current := []emoteservice.Emote{}
+	for _, emoteID := range emoteIDs {
+		current = append(current, emoteservice.Emote{ID: emoteID, Code: emoteID})
+	}
+
+	return removalRequest{channelUserID: "77829817", rewardType: dto.REWARD_SEVENTV, slots: slots, current: current, limit: limit}
+}
+
+func TestFifoRemovalRemovesOldestAdded(t *testing.T) {
+	db := &store.MockStore{EmoteAdded: []store.EmoteAdd{rewardEmoteAdd("new", 1), rewardEmoteAdd("old", 5)}}
+
+	emoteID, changeType, err := newRemovalStrategy(db, "").removalTarget(removalTestRequest(2, 10, "new", "old"))
+	assert.NoError(t, err)
+	assert.Equal(t, "old", emoteID)
+	assert.Equal(t, dto.EMOTE_ADD_REMOVED_PREVIOUS, changeType)
+}
+
+func TestFifoRemovalFallsBackToRandom(t *testing.T) {
+	db := &store.MockStore{EmoteAdded: []store.EmoteAdd{}}
+	strategy := &fifoRemoval{db: db, intn: func(n int) int { return n - 1 }}
+
+	emoteID, changeType, err := strategy.removalTarget(removalTestRequest(1, 2, "someone", "else"))
+	assert.NoError(t, err)
+	assert.Equal(t, "else", emoteID)
+	assert.Equal(t, dto.EMOTE_ADD_REMOVED_RANDOM, changeType)
+
+	emoteID, _, err = strategy.removalTarget(removalTestRequest(1, 3, "someone", "else"))
+	assert.NoError(t, err)
+	assert.Empty(t, emoteID, "nothing is removed while the channel has space")
+}
+
+func TestLeastRecentlyUsedRemoval(t *testing.T) {
+	db := &store.MockStore{
+		EmoteAdded:  []store.EmoteAdd{rewardEmoteAdd("a", 1), rewardEmoteAdd("b", 3), rewardEmoteAdd("c", 5)},
+		EmoteUsages: []store.EmoteUsage{usage("a", 0, 1), usage("b", 2, 50), usage("c", 1, 3)},
+	}
+
+	emoteID, changeType, err := newRemovalStrategy(db, dto.EMOTE_REMOVAL_LEAST_RECENTLY_USED).removalTarget(removalTestRequest(3, 10, "a", "b", "c"))
+	assert.NoError(t, err)
+	assert.Equal(t, "b", emoteID)
+	assert.Equal(t, dto.EMOTE_ADD_REMOVED_PREVIOUS, changeType)
+
+	db.EmoteUsages = []store.EmoteUsage{usage("a", 0, 1), usage("c", 0, 3)}
+	emoteID, _, err = newRemovalStrategy(db, dto.EMOTE_REMOVAL_LEAST_RECENTLY_USED).removalTarget(removalTestRequest(3, 10, "a", "b", "c"))
+	assert.NoError(t, err)
+	assert.Equal(t, "b", emoteID, "never used emotes go first")
+}
+
+func TestLeastUsedRemoval(t *testing.T) {
+	db := &store.MockStore{
+		EmoteAdded: []store.EmoteAdd{rewardEmoteAdd("a", 1), rewardEmoteAdd("b", 3), rewardEmoteAdd("c", 5)},
+		// usage of "c" before it was added by the reward doesn't count
+		EmoteUsages: []store.EmoteUsage{usage("a", 0, 2), usage("b", 2, 50), usage("c", 9, 100), usage("c", 1, 1)},
+	}
+
+	emoteID, _, err := newRemovalStrategy(db, dto.EMOTE_REMOVAL_LEAST_USED).removalTarget(removalTestRequest(3, 10, "a", "b", "c"))
+	assert.NoError(t, err)
+	assert.Equal(t, "c", emoteID)
+
+	db.EmoteUsages = []store.EmoteUsage{}
+	emoteID, _, err = newRemovalStrategy(db, dto.EMOTE_REMOVAL_LEAST_USED).removalTarget(removalTestRequest(3, 10, "a", "b", "c"))
+	assert.NoError(t, err)
+	assert.Equal(t, "c", emoteID, "oldest wins on equal usage")
+}
+
+func TestUsageRemovalKeepsFreeSlots(t *testing.T) {
+	db := &store.MockStore{EmoteAdded: []store.EmoteAdd{rewardEmoteAdd("a", 1)}}
+
+	emoteID, _, err := newRemovalStrategy(db, dto.EMOTE_REMOVAL_LEAST_USED).removalTarget(removalTestRequest(2, 10, "a", "other"))
+	assert.NoError(t, err)
+	assert.Empty(t, emoteID)
+}
+
+func TestRandomRemovalOnlyRemovesRewardEmotes(t *testing.T) {
+	db := &store.MockStore{EmoteAdded: []store.EmoteAdd{rewardEmoteAdd("a", 1), rewardEmoteAdd("gone", 2)}}
+	strategy := &randomRemoval{db: db, intn: func(n int) int { return n - 1 }}
+
+	emoteID, changeType, err := strategy.removalTarget(removalTestRequest(2, 2, "a", "other"))
+	assert.NoError(t, err)
+	assert.Equal(t, "a", emoteID, "emotes no longer in the channel and emotes added elsewhere are skipped")
+	assert.Equal(t, dto.EMOTE_ADD_REMOVED_RANDOM, changeType)
+
+	db.EmoteAdded = []store.EmoteAdd{}
+	_, _, err = strategy.removalTarget(removalTestRequest(2, 2, "someone", "else"))
+	assert.Error(t, err, "full channel without reward emotes")
+}
+
+func TestRefuseRemoval(t *testing.T) {
+	db := &store.MockStore{EmoteAdded: []store.EmoteAdd{rewardEmoteAdd("a", 1)}}
+
+	emoteID, _, err := newRemovalStrategy(db, dto.EMOTE_REMOVAL_REFUSE).removalTarget(removalTestRequest(2, 10, "a"))
+	assert.NoError(t, err)
+	assert.Empty(t, emoteID)
+
+	_, _, err = newRemovalStrategy(db, dto.EMOTE_REMOVAL_REFUSE).removalTarget(removalTestRequest(1, 10, "a"))
+	assert.Error(t, err)
+}
+
+func TestVerifySetSevenTvEmoteUsesStrategy(t *testing.T) {
+	ec := &EmoteChief{db: &store.MockStore{EmoteAdded: []store.EmoteAdd{}}, sevenTvClient: emoteservice.NewMockApiClient()}
+
+	_, removalTarget, _, err := ec.VerifySetSevenTvEmote("77829817", "emoteid", "gempir", "gempir", 1, dto.EMOTE_REMOVAL_REFUSE, dto.EmoteRules{})
+	assert.NoError(t, err, "mock channel has free emote slots")
+	assert.Empty(t, removalTarget)
+}
+
+func TestFifoRemovalSkipsProtectedEmotes(t *testing.T) {
+	db := &store.MockStore{EmoteAdded: []store.EmoteAdd{rewardEmoteAdd("new", 1), rewardEmoteAdd("old", 5)}}
+	req := removalTestRequest(2, 10, "new", "old")
+	req.protected = []store.EmoteProtection{{Type: dto.REWARD_SEVENTV, Emote: "old"}}
+
+	emoteID, _, err := newRemovalStrategy(db, "").removalTarget(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", emoteID)
+
+	req.protected = []store.EmoteProtection{{Type: dto.REWARD_BTTV, Emote: "old"}}
+	emoteID, _, err = newRemovalStrategy(db, "").removalTarget(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "old", emoteID, "protections of other providers don't apply")
+}
+
+func TestFifoRemovalRandomSkipsProtectedEmotes(t *testing.T) {
+	db := &store.MockStore{EmoteAdded: []store.EmoteAdd{}}
+	strategy := &fifoRemoval{db: db, intn: func(n int) int { return n - 1 }}
+
+	req := removalTestRequest(1, 3, "staple", "other", "pepe")
+	req.current[2].Code = "PepeLaugh"
+	req.protected = []store.EmoteProtection{{Type: dto.REWARD_SEVENTV, Emote: "PepeLaugh"}}
+
+	emoteID, changeType, err := strategy.removalTarget(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "other", emoteID, "protected by code")
+	assert.Equal(t, dto.EMOTE_ADD_REMOVED_RANDOM, changeType)
+
+	req.protected = append(req.protected, store.EmoteProtection{Type: dto.REWARD_SEVENTV, Emote: "staple"}, store.EmoteProtection{Type: dto.REWARD_SEVENTV, Emote: "other"})
+	_, _, err = strategy.removalTarget(req)
+	assert.ErrorIs(t, err, errAllProtected)
+}
+
+func TestUsageRemovalSkipsProtectedEmotes(t *testing.T) {
+	db := &store.MockStore{EmoteAdded: []store.EmoteAdd{rewardEmoteAdd("a", 1), rewardEmoteAdd("b", 3)}}
+	req := removalTestRequest(2, 2, "a", "b")
+	req.protected = []store.EmoteProtection{{Type: dto.REWARD_SEVENTV, Emote: "b"}}
+
+	emoteID, _, err := newRemovalStrategy(db, dto.EMOTE_REMOVAL_LEAST_USED).removalTarget(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", emoteID)
+
+	req.protected = append(req.protected, store.EmoteProtection{Type: dto.REWARD_SEVENTV, Emote: "a"})
+	_, _, err = newRemovalStrategy(db, dto.EMOTE_REMOVAL_RANDOM).removalTarget(req)
+	assert.ErrorIs(t, err, errAllProtected)
+}
+
+func TestDescribeEmoteFollowsRemovalStrategy(t *testing.T) {
+	db := &store.MockStore{EmoteAdded: []store.EmoteAdd{rewardEmoteAdd("new", 1), rewardEmoteAdd("old", 5)}}
+	ec := &EmoteChief{db: db}
+	reward := func(options string) []store.ChannelPointReward {
+		return []store.ChannelPointReward{{OwnerTwitchID: "77829817", Type: dto.REWARD_SEVENTV, AdditionalOptions: options}}
+	}
+
+	db.ChannelPointRewards = reward(`{"Slots":2}`)
+	assert.True(t, strings.HasSuffix(ec.describeEmote("77829817", dto.REWARD_SEVENTV, "new", "catJAM"), ", 1 more redemptions until it will be replaced"))
+
+	db.ChannelPointRewards = reward(`{"Slots":2,"RemovalStrategy":"least_used"}`)
+	assert.True(t, strings.HasSuffix(ec.describeEmote("77829817", dto.REWARD_SEVENTV, "new", "catJAM"), ", redemptions replace the least used emote"))
+
+	db.ChannelPointRewards = reward(`{"Slots":2,"RemovalStrategy":"refuse"}`)
+	assert.True(t, strings.HasSuffix(ec.describeEmote("77829817", dto.REWARD_SEVENTV, "new", "catJAM"), " ago"))
+
+	db.ChannelPointRewards = reward(`{"Slots":2}`)
+	db.EmoteProtections = []store.EmoteProtection{{Type: dto.REWARD_SEVENTV, Emote: "catJAM"}}
+	assert.True(t, strings.HasSuffix(ec.describeEmote("77829817", dto.REWARD_SEVENTV, "new", "catJAM"), ", it is protected and never removed"))
+}
+
+type enabledDryRun struct {
+	recorded []string
+}
+
+func (d *enabledDryRun) Enabled(channelID string) bool { return true }
+
+func (d *enabledDryRun) Record(channelID string, action string, details string) {
+	d.recorded = append(d.recorded, action)
+}
+
+func TestDryRunSkipsEmoteHistory(t *testing.T) {
+	db := &store.MockStore{EmoteAdded: []store.EmoteAdd{}}
+	ec := &EmoteChief{db: db, sevenTvClient: emoteservice.NewMockApiClient(), dryRun: &enabledDryRun{}}
+
+	_, _, err := ec.setSevenTvEmote("77829817", "emoteid", "gempir", "gempir", "1", 1, "", dto.EmoteRules{}, dto.EMOTE_ADD_ADD)
+	assert.NoError(t, err)
+	assert.Empty(t, db.CreatedEmoteAdds, "dry-run changes must not affect removal targets or expiry")
+}
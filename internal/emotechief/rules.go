@@ -0,0 +1,54 @@
+package emotechief
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/emoteservice"
+)
+
+// checkEmoteRules returns an error for the first rule of the reward the emote breaks
+func checkEmoteRules(rules dto.EmoteRules, emote emoteservice.Emote) error {
+	if rules.DenyAnimated && emote.Animated {
+		return errors.New("animated emotes are not allowed")
+	}
+	if rules.DenyZeroWidth && emote.ZeroWidth {
+		return errors.New("zero-width emotes are not allowed")
+	}
+	if rules.DenyUnlisted && emote.Unlisted {
+		return errors.New("unlisted emotes are not allowed")
+	}
+	if rules.MaxCodeLength > 0 && utf8.RuneCountInString(emote.Code) > rules.MaxCodeLength {
+		return fmt.Errorf("emote code \"%s\" is longer than %d characters", emote.Code, rules.MaxCodeLength)
+	}
+
+	if rules.AllowedCodePattern != "" {
+		allowed, err := regexp.Compile(rules.AllowedCodePattern)
+		if err != nil {
+			return err
+		}
+		if !allowed.MatchString(emote.Code) {
+			return fmt.Errorf("emote code \"%s\" is not allowed", emote.Code)
+		}
+	}
+	if rules.DeniedCodePattern != "" {
+		denied, err := regexp.Compile(rules.DeniedCodePattern)
+		if err != nil {
+			return err
+		}
+		if denied.MatchString(emote.Code) {
+			return fmt.Errorf("emote code \"%s\" is not allowed", emote.Code)
+		}
+	}
+
+	for _, uploader := range rules.DeniedUploaders {
+		if uploader != "" && uploader == emote.OwnerID {
+			return errors.New("emotes of this uploader are not allowed")
+		}
+	}
+
+	return nil
+}
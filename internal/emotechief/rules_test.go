@@ -0,0 +1,68 @@
+package emotechief
+
+import (
+	"testing"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/emoteservice"
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckEmoteRules(t *testing.T) {
+	emote := emoteservice.Emote{ID: "60aed4fe423a803ccae373d3", Code: "PepeLaugh", OwnerID: "uploader"}
+	animated := emoteservice.Emote{ID: "1", Code: "catJAM", Animated: true}
+	zeroWidth := emoteservice.Emote{ID: "2", Code: "RainTime", ZeroWidth: true}
+	unlisted := emoteservice.Emote{ID: "3", Code: "weirdChamp", Unlisted: true}
+
+	tests := []struct {
+		name  string
+		rules dto.EmoteRules
+		emote emoteservice.Emote
+		valid bool
+	}{
+		{"no rules", dto.EmoteRules{}, animated, true},
+		{"deny animated", dto.EmoteRules{DenyAnimated: true}, animated, false},
+		{"deny animated static emote", dto.EmoteRules{DenyAnimated: true}, emote, true},
+		{"deny zero-width", dto.EmoteRules{DenyZeroWidth: true}, zeroWidth, false},
+		{"deny unlisted", dto.EmoteRules{DenyUnlisted: true}, unlisted, false},
+		{"allowed pattern", dto.EmoteRules{AllowedCodePattern: "^Pepe"}, emote, true},
+		{"not allowed pattern", dto.EmoteRules{AllowedCodePattern: "^Pepe"}, animated, false},
+		{"denied pattern", dto.EmoteRules{DeniedCodePattern: "(?i)laugh"}, emote, false},
+		{"denied uploader", dto.EmoteRules{DeniedUploaders: []string{"someone", "uploader"}}, emote, false},
+		{"other uploader", dto.EmoteRules{DeniedUploaders: []string{"someone"}}, emote, true},
+		{"max code length", dto.EmoteRules{MaxCodeLength: 8}, emote, false},
+		{"code within length", dto.EmoteRules{MaxCodeLength: 9}, emote, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkEmoteRules(test.rules, test.emote)
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+type rulesApiClient struct {
+	emoteservice.MockApiClient
+	emote emoteservice.Emote
+}
+
+func (c *rulesApiClient) GetEmote(emoteID string) (emoteservice.Emote, error) {
+	return c.emote, nil
+}
+
+func TestVerifySetSevenTvEmoteChecksRules(t *testing.T) {
+	apiClient := &rulesApiClient{emote: emoteservice.Emote{ID: "emoteid", Code: "catJAM", Animated: true}}
+	ec := &EmoteChief{db: &store.MockStore{EmoteAdded: []store.EmoteAdd{}}, sevenTvClient: apiClient}
+
+	_, _, _, err := ec.VerifySetSevenTvEmote("77829817", "emoteid", "gempir", "gempir", 1, dto.EMOTE_REMOVAL_FIFO, dto.EmoteRules{DenyAnimated: true})
+	assert.EqualError(t, err, "animated emotes are not allowed")
+
+	_, _, _, err = ec.VerifySetSevenTvEmote("77829817", "emoteid", "gempir", "gempir", 1, dto.EMOTE_REMOVAL_FIFO, dto.EmoteRules{DenyZeroWidth: true})
+	assert.NoError(t, err)
+}
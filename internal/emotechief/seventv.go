@@ -3,20 +3,20 @@ package emotechief
 import (
 	"errors"
 	"fmt"
-	"math/rand"
 	"regexp"
 
 	"github.com/gempir/gempbot/internal/channelpoint"
 	"github.com/gempir/gempbot/internal/dto"
 	"github.com/gempir/gempbot/internal/emoteservice"
 	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/messages"
 	"github.com/gempir/gempbot/internal/store"
 	"github.com/nicklaw5/helix/v2"
 )
 
 var sevenTvRegex = regexp.MustCompile(`https?:\/\/(?:next\.)?7tv.app\/emotes\/(\w*)`)
 
-func (ec *EmoteChief) VerifySetSevenTvEmote(channelUserID, emoteId, channel, redeemedByUsername string, slots int) (emoteAddType dto.EmoteChangeType, removalTargetEmoteId string, nextEmote emoteservice.Emote, err error) {
+func (ec *EmoteChief) VerifySetSevenTvEmote(channelUserID, emoteId, channel, redeemedByUsername string, slots int, strategy dto.EmoteRemovalStrategy, rules dto.EmoteRules) (emoteAddType dto.EmoteChangeType, removalTargetEmoteId string, nextEmote emoteservice.Emote, err error) {
 	if ec.db.IsEmoteBlocked(channelUserID, emoteId, dto.REWARD_SEVENTV) {
 		return dto.EMOTE_ADD_ADD, "", emoteservice.Emote{}, errors.New("emote is blocked")
 	}
@@ -26,6 +26,11 @@ func (ec *EmoteChief) VerifySetSevenTvEmote(channelUserID, emoteId, channel, red
 		return
 	}
 
+	err = checkEmoteRules(rules, nextEmote)
+	if err != nil {
+		return dto.EMOTE_ADD_ADD, "", emoteservice.Emote{}, err
+	}
+
 	user, err := ec.sevenTvClient.GetUser(channelUserID)
 	if err != nil {
 		return
@@ -38,39 +43,31 @@ func (ec *EmoteChief) VerifySetSevenTvEmote(channelUserID, emoteId, channel, red
 	}
 	log.Infof("Current 7TV emotes: %d/%d", len(user.Emotes), user.EmoteSlots)
 
-	emotesAdded := ec.db.GetEmoteAdded(channelUserID, dto.REWARD_SEVENTV, slots)
-	log.Infof("Total Previous emotes %d in %s", len(emotesAdded), channelUserID)
-
-	if len(emotesAdded) > 0 {
-		oldestEmote := emotesAdded[len(emotesAdded)-1]
-		if !oldestEmote.Blocked {
-			for _, sharedEmote := range user.Emotes {
-				if oldestEmote.EmoteID == sharedEmote.ID {
-					removalTargetEmoteId = oldestEmote.EmoteID
-					log.Infof("Found removal target %s in %s", removalTargetEmoteId, channelUserID)
-				}
-			}
-		} else {
-			log.Infof("Removal target %s is already blocked, so already removed, skipping removal", oldestEmote.EmoteID)
+	// without slots, e.g. when added by a moderator, nothing gets replaced
+	if slots < 1 {
+		if len(user.Emotes) >= user.EmoteSlots {
+			err = errors.New("emotes limit reached, remove an emote first")
 		}
+		return
 	}
 
-	emoteAddType = dto.EMOTE_ADD_REMOVED_PREVIOUS
-	if removalTargetEmoteId == "" && len(user.Emotes) >= user.EmoteSlots {
-		if len(user.Emotes) == 0 {
-			return dto.EMOTE_ADD_ADD, "", emoteservice.Emote{}, errors.New("emotes limit reached and can't find amount of emotes added to choose random")
-		}
-
-		emoteAddType = dto.EMOTE_ADD_REMOVED_RANDOM
-		log.Infof("Didn't find previous emote history of %d emotes and limit reached, choosing random in %s", slots, channelUserID)
-		removalTargetEmoteId = user.Emotes[rand.Intn(len(user.Emotes))].ID
+	removalTargetEmoteId, emoteAddType, err = newRemovalStrategy(ec.db, strategy).removalTarget(removalRequest{
+		channelUserID: channelUserID,
+		rewardType:    dto.REWARD_SEVENTV,
+		slots:         slots,
+		current:       user.Emotes,
+		limit:         user.EmoteSlots,
+		protected:     ec.db.GetEmoteProtections(channelUserID),
+	})
+	if err != nil {
+		return dto.EMOTE_ADD_ADD, "", emoteservice.Emote{}, err
 	}
 
 	return
 }
 
-func (ec *EmoteChief) setSevenTvEmote(channelUserID, emoteId, channel, redeemedByUsername string, redeemedByUserID string, slots int) (addedEmoteId string, removedEmoteID string, err error) {
-	emoteAddType, removalTargetEmoteId, _, err := ec.VerifySetSevenTvEmote(channelUserID, emoteId, channel, redeemedByUsername, slots)
+func (ec *EmoteChief) setSevenTvEmote(channelUserID, emoteId, channel, redeemedByUsername string, redeemedByUserID string, slots int, strategy dto.EmoteRemovalStrategy, rules dto.EmoteRules, changeType dto.EmoteChangeType) (addedEmoteId string, removedEmoteID string, err error) {
+	emoteAddType, removalTargetEmoteId, _, err := ec.VerifySetSevenTvEmote(channelUserID, emoteId, channel, redeemedByUsername, slots, strategy, rules)
 	if err != nil {
 		return "", "", err
 	}
@@ -82,7 +79,7 @@ func (ec *EmoteChief) setSevenTvEmote(channelUserID, emoteId, channel, redeemedB
 			return "", "", err
 		}
 
-		ec.db.CreateEmoteAdd(channelUserID, dto.REWARD_SEVENTV, removalTargetEmoteId, emoteAddType)
+		ec.createEmoteAdd(channelUserID, dto.REWARD_SEVENTV, removalTargetEmoteId, emoteAddType)
 	}
 
 	err = ec.sevenTvClient.AddEmote(channelUserID, emoteId)
@@ -90,7 +87,7 @@ func (ec *EmoteChief) setSevenTvEmote(channelUserID, emoteId, channel, redeemedB
 		return "", removalTargetEmoteId, err
 	}
 
-	ec.db.CreateEmoteAdd(channelUserID, dto.REWARD_SEVENTV, emoteId, dto.EMOTE_ADD_ADD)
+	ec.createEmoteAdd(channelUserID, dto.REWARD_SEVENTV, emoteId, changeType)
 
 	return emoteId, removalTargetEmoteId, nil
 }
@@ -110,17 +107,17 @@ func (ec *EmoteChief) VerifySeventvRedemption(reward store.ChannelPointReward, r
 
 	emoteID, err := GetSevenTvEmoteId(redemption.UserInput)
 	if err == nil {
-		_, _, _, err := ec.VerifySetSevenTvEmote(redemption.BroadcasterUserID, emoteID, redemption.BroadcasterUserLogin, redemption.UserLogin, opts.Slots)
+		_, _, _, err := ec.VerifySetSevenTvEmote(redemption.BroadcasterUserID, emoteID, redemption.BroadcasterUserLogin, redemption.UserLogin, opts.Slots, opts.RemovalStrategy, opts.Rules)
 		if err != nil {
 			log.Warnf("7TV error %s %s", redemption.BroadcasterUserLogin, err)
-			ec.chatClient.Say(redemption.BroadcasterUserLogin, fmt.Sprintf("⚠️ Failed to add 7TV emote from @%s error: %s", redemption.UserName, err.Error()))
+			ec.sayRedemption(redemption, messages.EmoteSevenTvRedemptionFailed, messages.Values{"error": err.Error()})
 			return false
 		}
 
 		return true
 	}
 
-	ec.chatClient.Say(redemption.BroadcasterUserLogin, fmt.Sprintf("⚠️ Failed to add 7TV emote from @%s error: %s", redemption.UserName, err.Error()))
+	ec.sayRedemption(redemption, messages.EmoteSevenTvRedemptionFailed, messages.Values{"error": err.Error()})
 	return false
 }
 
@@ -131,7 +128,7 @@ func (ec *EmoteChief) HandleSeventvRedemption(reward store.ChannelPointReward, r
 	emoteID, err := GetSevenTvEmoteId(redemption.UserInput)
 	if err == nil {
 		log.Infof("Seen 7TV emote link %s", emoteID)
-		added, removed, settingErr := ec.setSevenTvEmote(redemption.BroadcasterUserID, emoteID, redemption.BroadcasterUserLogin, redemption.UserName, redemption.UserID, opts.Slots)
+		added, removed, settingErr := ec.setSevenTvEmote(redemption.BroadcasterUserID, emoteID, redemption.BroadcasterUserLogin, redemption.UserName, redemption.UserID, opts.Slots, opts.RemovalStrategy, opts.Rules, dto.EMOTE_ADD_ADD)
 		addedEmote, err := ec.sevenTvClient.GetEmote(added)
 		if err != nil && len(added) > 0 {
 			log.Error("Error fetching added emote: " + err.Error())
@@ -143,19 +140,19 @@ func (ec *EmoteChief) HandleSeventvRedemption(reward store.ChannelPointReward, r
 
 		if settingErr != nil {
 			log.Warnf("7TV error %s %s", redemption.BroadcasterUserLogin, settingErr)
-			ec.chatClient.Say(redemption.BroadcasterUserLogin, fmt.Sprintf("⚠️ Failed to add 7TV emote from @%s %s", redemption.UserName, settingErr.Error()))
+			ec.sayRedemption(redemption, messages.EmoteSevenTvRedemptionFailed, messages.Values{"error": settingErr.Error()})
 		} else if addedEmote.Code != "" && removedEmote.Code != "" {
 			success = true
-			ec.chatClient.Say(redemption.BroadcasterUserLogin, fmt.Sprintf("✅ Added new 7TV emote %s redeemed by @%s removed %s", addedEmote.Code, redemption.UserName, removedEmote.Code))
+			ec.sayRedemption(redemption, messages.EmoteSevenTvRedeemedReplaced, messages.Values{"emote": addedEmote.Code, "removed": removedEmote.Code})
 		} else if addedEmote.Code != "" {
 			success = true
-			ec.chatClient.Say(redemption.BroadcasterUserLogin, fmt.Sprintf("✅ Added new 7TV emote %s redeemed by @%s", addedEmote.Code, redemption.UserName))
+			ec.sayRedemption(redemption, messages.EmoteSevenTvRedeemed, messages.Values{"emote": addedEmote.Code})
 		} else {
 			success = true
-			ec.chatClient.Say(redemption.BroadcasterUserLogin, fmt.Sprintf("✅ Added new 7TV emote [unknown] redeemed by @%s", redemption.UserName))
+			ec.sayRedemption(redemption, messages.EmoteSevenTvRedeemed, messages.Values{"emote": "[unknown]"})
 		}
 	} else {
-		ec.chatClient.Say(redemption.BroadcasterUserLogin, fmt.Sprintf("⚠️ Failed to add 7TV emote from @%s %s", redemption.UserName, err.Error()))
+		ec.sayRedemption(redemption, messages.EmoteSevenTvRedemptionFailed, messages.Values{"error": err.Error()})
 	}
 
 	if redemption.UserID == dto.GEMPIR_USER_ID {
@@ -10,6 +10,7 @@ import (
 	"github.com/gempir/gempbot/internal/emotechief"
 	"github.com/gempir/gempbot/internal/emoteservice"
 	"github.com/gempir/gempbot/internal/helixclient"
+	"github.com/gempir/gempbot/internal/messages"
 	"github.com/gempir/gempbot/internal/store"
 	"github.com/nicklaw5/helix/v2"
 	"github.com/stretchr/testify/assert"
@@ -37,7 +38,7 @@ func TestCanGetSevenTvEmoteFromMessage(t *testing.T) {
 }
 
 func TestCanNotVerifySevenTvEmoteRedemption(t *testing.T) {
-	ec := emotechief.NewEmoteChief(config.NewMockConfig(), &store.Database{}, helixclient.NewMockClient(), chat.NewClient(config.NewMockConfig()), emoteservice.NewSevenTvClient(store.NewMockStore()))
+	ec := emotechief.NewEmoteChief(config.NewMockConfig(), &store.Database{}, helixclient.NewMockClient(), messages.NewMessenger(store.NewMockStore(), chat.NewClient(config.NewMockConfig()).Say), emoteservice.NewSevenTvClient(store.NewMockStore()))
 
 	opts := channelpoint.BttvAdditionalOptions{Slots: 1}
 	marshalled, _ := json.Marshal(opts)
@@ -51,7 +52,7 @@ func TestCanNotVerifySevenTvEmoteRedemption(t *testing.T) {
 
 func TestCanVerifySevenTvEmoteRedemption(t *testing.T) {
 	cfg := config.NewMockConfig()
-	ec := emotechief.NewEmoteChief(cfg, store.NewMockStore(), helixclient.NewMockClient(), chat.NewClient(cfg), emoteservice.NewMockApiClient())
+	ec := emotechief.NewEmoteChief(cfg, store.NewMockStore(), helixclient.NewMockClient(), messages.NewMessenger(store.NewMockStore(), chat.NewClient(cfg).Say), emoteservice.NewMockApiClient())
 
 	opts := channelpoint.BttvAdditionalOptions{Slots: 1}
 	marshalled, _ := json.Marshal(opts)
@@ -67,7 +68,7 @@ func TestCanHandleSevenTvEmoteRedemption(t *testing.T) {
 	cfg := config.NewMockConfig()
 	db := store.NewMockStore()
 
-	ec := emotechief.NewEmoteChief(cfg, db, helixclient.NewClient(cfg, db), chat.NewClient(cfg), emoteservice.NewMockApiClient())
+	ec := emotechief.NewEmoteChief(cfg, db, helixclient.NewClient(cfg, db), messages.NewMessenger(store.NewMockStore(), chat.NewClient(cfg).Say), emoteservice.NewMockApiClient())
 
 	opts := channelpoint.BttvAdditionalOptions{Slots: 1}
 	marshalled, _ := json.Marshal(opts)
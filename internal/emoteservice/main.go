@@ -3,6 +3,11 @@ package emoteservice
 type Emote struct {
 	ID   string
 	Code string
+	// the details below are only filled by GetEmote
+	Animated  bool
+	ZeroWidth bool
+	Unlisted  bool
+	OwnerID   string
 }
 
 type User struct {
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/carlmjohnson/requests"
+	"github.com/gempir/gempbot/internal/dto"
 	"github.com/gempir/gempbot/internal/log"
 	"github.com/gempir/gempbot/internal/store"
 )
@@ -17,6 +18,12 @@ type SevenTvClient struct {
 	store      store.Store
 	apiBaseUrl string
 	gqlBaseUrl string
+	dryRun     dryRun
+}
+
+type dryRun interface {
+	Enabled(channelID string) bool
+	Record(channelID string, action string, details string)
 }
 
 func NewSevenTvClient(store store.Store) *SevenTvClient {
@@ -27,6 +34,11 @@ func NewSevenTvClient(store store.Store) *SevenTvClient {
 	}
 }
 
+// SetDryRun records emote changes in dry-run channels instead of sending them to 7TV
+func (c *SevenTvClient) SetDryRun(dryRun dryRun) {
+	c.dryRun = dryRun
+}
+
 type UserV3 struct {
 	ID                string `json:"id"`
 	Username          string `json:"username"`
@@ -89,9 +101,19 @@ func (c *SevenTvClient) GetEmote(emoteID string) (Emote, error) {
 		ToJSON(&emoteData).
 		Fetch(context.Background())
 
-	return Emote{Code: emoteData.Name, ID: emoteData.ID}, err
+	return Emote{
+		Code:      emoteData.Name,
+		ID:        emoteData.ID,
+		Animated:  emoteData.Animated,
+		ZeroWidth: emoteData.Flags&sevenTvEmoteFlagZeroWidth != 0,
+		Unlisted:  emoteData.ID != "" && !emoteData.Listed,
+		OwnerID:   emoteData.Owner.ID,
+	}, err
 }
 
+// sevenTvEmoteFlagZeroWidth marks emotes that overlay the previous emote
+const sevenTvEmoteFlagZeroWidth = 1 << 8
+
 type ChangeEmoteResponse struct {
 	Errors []struct {
 		Message    string   `json:"message"`
@@ -109,6 +131,11 @@ type ChangeEmoteResponse struct {
 }
 
 func (c *SevenTvClient) RemoveEmote(channelUserID, emoteID string) error {
+	if c.dryRun != nil && c.dryRun.Enabled(channelUserID) {
+		c.dryRun.Record(channelUserID, dto.DryRunActionRemoveEmote, "7tv "+emoteID)
+		return nil
+	}
+
 	connection, err := c.GetTwitchConnection(channelUserID)
 	if err != nil {
 		return errors.New("Could not find 7TV twitch connection for user " + err.Error())
@@ -144,6 +171,11 @@ func (c *SevenTvClient) RemoveEmote(channelUserID, emoteID string) error {
 }
 
 func (c *SevenTvClient) AddEmote(channelUserID, emoteID string) error {
+	if c.dryRun != nil && c.dryRun.Enabled(channelUserID) {
+		c.dryRun.Record(channelUserID, dto.DryRunActionAddEmote, "7tv "+emoteID)
+		return nil
+	}
+
 	connection, err := c.GetTwitchConnection(channelUserID)
 	if err != nil {
 		return errors.New("Could not find 7TV twitch connection for user " + err.Error())
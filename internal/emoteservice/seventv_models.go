@@ -1,9 +1,12 @@
 package emoteservice
 
 type sevenTvEmote struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Owner struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Flags    int    `json:"flags"`
+	Listed   bool   `json:"listed"`
+	Animated bool   `json:"animated"`
+	Owner    struct {
 		ID          string `json:"id"`
 		TwitchID    string `json:"twitch_id"`
 		Login       string `json:"login"`
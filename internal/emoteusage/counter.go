@@ -0,0 +1,168 @@
+package emoteusage
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/emoteservice"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/gempir/go-twitch-irc/v4"
+)
+
+const (
+	flushInterval  = time.Minute
+	emotesCacheTtl = 10 * time.Minute
+)
+
+type storage interface {
+	AddEmoteUsages(ctx context.Context, usages []store.EmoteUsage) error
+}
+
+type emote struct {
+	id         string
+	rewardType dto.RewardType
+}
+
+type channelEmotes struct {
+	// emotes by code, nil until loaded
+	emotes   map[string]emote
+	loadedAt time.Time
+	loading  bool
+}
+
+type usageKey struct {
+	channelID  string
+	rewardType dto.RewardType
+	emoteID    string
+	day        time.Time
+}
+
+// Counter counts the messages using the 7TV and BTTV emotes of a channel, the counts are kept in memory and flushed periodically
+type Counter struct {
+	db         storage
+	sevenTv    emoteservice.ApiClient
+	bttvEmotes func(channelID string) ([]emoteservice.Emote, error)
+	now        func() time.Time
+
+	mu       sync.Mutex
+	channels map[string]*channelEmotes
+	counts   map[usageKey]int
+	codes    map[usageKey]string
+}
+
+func NewCounter(db storage, sevenTv emoteservice.ApiClient, bttvEmotes func(channelID string) ([]emoteservice.Emote, error)) *Counter {
+	return &Counter{
+		db:         db,
+		sevenTv:    sevenTv,
+		bttvEmotes: bttvEmotes,
+		now:        time.Now,
+		channels:   map[string]*channelEmotes{},
+		counts:     map[usageKey]int{},
+		codes:      map[usageKey]string{},
+	}
+}
+
+// Start flushes the counts every minute
+func (c *Counter) Start() {
+	for range time.NewTicker(flushInterval).C {
+		c.Flush()
+	}
+}
+
+// HandlePrivateMessage counts every emote once per message, the emotes of a channel are loaded in the background on its first message
+func (c *Counter) HandlePrivateMessage(msg twitch.PrivateMessage) {
+	now := c.now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	channel, ok := c.channels[msg.RoomID]
+	if !ok {
+		channel = &channelEmotes{}
+		c.channels[msg.RoomID] = channel
+	}
+	if !channel.loading && now.Sub(channel.loadedAt) >= emotesCacheTtl {
+		channel.loading = true
+		go c.loadEmotes(msg.RoomID)
+	}
+	if channel.emotes == nil {
+		return
+	}
+
+	day := truncateDay(now)
+	counted := map[string]bool{}
+	for _, word := range strings.Fields(msg.Message) {
+		e, ok := channel.emotes[word]
+		if !ok || counted[word] {
+			continue
+		}
+		counted[word] = true
+
+		key := usageKey{channelID: msg.RoomID, rewardType: e.rewardType, emoteID: e.id, day: day}
+		c.counts[key]++
+		c.codes[key] = word
+	}
+}
+
+// Flush writes the counts to the daily table, failed counts are kept for the next flush
+func (c *Counter) Flush() {
+	c.mu.Lock()
+	counts, codes := c.counts, c.codes
+	c.counts, c.codes = map[usageKey]int{}, map[usageKey]string{}
+	c.mu.Unlock()
+
+	usages := []store.EmoteUsage{}
+	for key, count := range counts {
+		usages = append(usages, store.EmoteUsage{ChannelTwitchID: key.channelID, Type: key.rewardType, EmoteID: key.emoteID, Day: key.day, Code: codes[key], Count: count})
+	}
+
+	err := c.db.AddEmoteUsages(context.Background(), usages)
+	if err != nil {
+		log.Errorf("failed to save %d emote usages: %s", len(usages), err)
+
+		c.mu.Lock()
+		for key, count := range counts {
+			c.counts[key] += count
+			c.codes[key] = codes[key]
+		}
+		c.mu.Unlock()
+	}
+}
+
+func (c *Counter) loadEmotes(channelID string) {
+	emotes := map[string]emote{}
+
+	bttvEmotes, err := c.bttvEmotes(channelID)
+	if err != nil {
+		log.Debugf("failed to load bttv emotes of %s: %s", channelID, err)
+	}
+	for _, e := range bttvEmotes {
+		emotes[e.Code] = emote{id: e.ID, rewardType: dto.REWARD_BTTV}
+	}
+
+	// 7TV wins when both have an emote with the same code
+	user, err := c.sevenTv.GetUser(channelID)
+	if err != nil {
+		log.Debugf("failed to load 7TV emotes of %s: %s", channelID, err)
+	}
+	for _, e := range user.Emotes {
+		emotes[e.Code] = emote{id: e.ID, rewardType: dto.REWARD_SEVENTV}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	channel := c.channels[channelID]
+	channel.emotes = emotes
+	channel.loadedAt = c.now()
+	channel.loading = false
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,62 @@
+package emoteusage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/emoteservice"
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/gempir/go-twitch-irc/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockStorage struct {
+	usages []store.EmoteUsage
+}
+
+func (s *mockStorage) AddEmoteUsages(ctx context.Context, usages []store.EmoteUsage) error {
+	s.usages = append(s.usages, usages...)
+	return nil
+}
+
+func TestCounterCountsEmotesOncePerMessage(t *testing.T) {
+	db := &mockStorage{}
+	c := NewCounter(db, emoteservice.NewMockApiClient(), func(channelID string) ([]emoteservice.Emote, error) {
+		return []emoteservice.Emote{{ID: "bttv1", Code: "KEKW"}, {ID: "bttv2", Code: "Clap"}}, nil
+	})
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	c.channels["77829817"] = &channelEmotes{loading: true}
+	c.loadEmotes("77829817")
+
+	c.HandlePrivateMessage(twitch.PrivateMessage{RoomID: "77829817", Message: "KEKW KEKW Clap"})
+	c.HandlePrivateMessage(twitch.PrivateMessage{RoomID: "77829817", Message: "KEKW kekw"})
+	c.Flush()
+
+	counts := map[string]int{}
+	for _, usage := range db.usages {
+		assert.Equal(t, dto.REWARD_BTTV, usage.Type)
+		assert.Equal(t, time.Date(2023, 5, 10, 0, 0, 0, 0, time.UTC), usage.Day)
+		counts[usage.Code] = usage.Count
+	}
+	assert.Equal(t, map[string]int{"KEKW": 2, "Clap": 1}, counts)
+
+	db.usages = nil
+	c.Flush()
+	assert.Len(t, db.usages, 0, "counts are reset after flushing")
+}
+
+func TestCounterSkipsChannelsWithoutEmotes(t *testing.T) {
+	db := &mockStorage{}
+	c := NewCounter(db, emoteservice.NewMockApiClient(), func(channelID string) ([]emoteservice.Emote, error) {
+		return nil, nil
+	})
+	c.channels["77829817"] = &channelEmotes{loading: true}
+
+	c.HandlePrivateMessage(twitch.PrivateMessage{RoomID: "77829817", Message: "KEKW"})
+	c.Flush()
+	assert.Len(t, db.usages, 0)
+}
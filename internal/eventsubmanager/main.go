@@ -9,12 +9,12 @@ import (
 
 	"github.com/ReneKroon/ttlcache/v2"
 	"github.com/gempir/gempbot/internal/api"
-	"github.com/gempir/gempbot/internal/chat"
 	"github.com/gempir/gempbot/internal/config"
 	"github.com/gempir/gempbot/internal/dto"
 	"github.com/gempir/gempbot/internal/emotechief"
 	"github.com/gempir/gempbot/internal/helixclient"
 	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/messages"
 	"github.com/gempir/gempbot/internal/store"
 	"github.com/nicklaw5/helix/v2"
 )
@@ -24,12 +24,15 @@ type EventsubManager struct {
 	helixClient helixclient.Client
 	db          *store.Database
 	emoteChief  *emotechief.EmoteChief
-	chatClient  *chat.ChatClient
+	messenger   *messages.Messenger
 	ttlCache    *ttlcache.Cache
 	callbackMap map[dto.RewardType]func(reward store.ChannelPointReward, redemption helix.EventSubChannelPointsCustomRewardRedemptionEvent)
+
+	streamStatusCallback func(channelID, channelLogin string, live bool)
+	raidCallback         func(raid helix.EventSubChannelRaidEvent)
 }
 
-func NewEventsubManager(cfg *config.Config, helixClient helixclient.Client, db *store.Database, emoteChief *emotechief.EmoteChief, bot *chat.ChatClient) *EventsubManager {
+func NewEventsubManager(cfg *config.Config, helixClient helixclient.Client, db *store.Database, emoteChief *emotechief.EmoteChief, messenger *messages.Messenger) *EventsubManager {
 	cache := ttlcache.NewCache()
 	err := cache.SetTTL(time.Second * 60)
 	if err != nil {
@@ -41,7 +44,7 @@ func NewEventsubManager(cfg *config.Config, helixClient helixclient.Client, db *
 		helixClient: helixClient,
 		db:          db,
 		emoteChief:  emoteChief,
-		chatClient:  bot,
+		messenger:   messenger,
 		ttlCache:    cache,
 		callbackMap: map[dto.RewardType]func(reward store.ChannelPointReward, redemption helix.EventSubChannelPointsCustomRewardRedemptionEvent){},
 	}
@@ -152,7 +155,7 @@ func (esm *EventsubManager) HandleChannelPointsCustomRewardRedemption(event []by
 					}
 				} else {
 					log.Infof("[%s] Bttv Reward is approve only, skipping redemption %s", redemption.BroadcasterUserID, redemption.Status)
-					esm.chatClient.Say(redemption.BroadcasterUserLogin, fmt.Sprintf("A new Bttv emote is waiting for approval, redeemed by @%s", redemption.UserName))
+					esm.messenger.Say(redemption.BroadcasterUserID, redemption.BroadcasterUserLogin, messages.EmoteBttvApproval, messages.Values{"user": redemption.UserName})
 					return
 				}
 			}
@@ -169,7 +172,7 @@ func (esm *EventsubManager) HandleChannelPointsCustomRewardRedemption(event []by
 					}
 				} else {
 					log.Infof("[%s] 7TV Reward is approve only, skipping redemption %s", redemption.BroadcasterUserID, redemption.Status)
-					esm.chatClient.Say(redemption.BroadcasterUserLogin, fmt.Sprintf("A new 7TV emote is waiting for approval, redeemed by @%s", redemption.UserName))
+					esm.messenger.Say(redemption.BroadcasterUserID, redemption.BroadcasterUserLogin, messages.EmoteSevenTvApproval, messages.Values{"user": redemption.UserName})
 					return
 				}
 			}
@@ -212,7 +215,7 @@ func (esm *EventsubManager) HandleChannelPointsCustomRewardRedemption(event []by
 			}
 			// if we don't find the redemption in our cache, we didn't send the redemption update ourselves and need to send a rejection message
 			if _, err := esm.ttlCache.Get(redemption.ID); err == ttlcache.ErrNotFound {
-				esm.chatClient.Say(redemption.BroadcasterUserLogin, fmt.Sprintf("⚠️ Emote redemption by @%s was rejected", redemption.UserLogin))
+				esm.messenger.Say(redemption.BroadcasterUserID, redemption.BroadcasterUserLogin, messages.EmoteRedemptionRejected, messages.Values{"user": redemption.UserLogin})
 			}
 		}
 		return
@@ -241,6 +244,11 @@ func (esm *EventsubManager) RefreshAllEventsubSubscriptions() {
 			esm.SubscribePredictionsBegin(sub.TargetTwitchID)
 			time.Sleep(time.Millisecond * 100)
 		}
+		if sub.Type == helix.EventSubTypeChannelPredictionProgress {
+			_ = esm.RemoveEventSubSubscription(sub.SubscriptionID)
+			esm.SubscribePredictionsProgress(sub.TargetTwitchID)
+			time.Sleep(time.Millisecond * 100)
+		}
 		if sub.Type == helix.EventSubTypeChannelPredictionEnd {
 			_ = esm.RemoveEventSubSubscription(sub.SubscriptionID)
 			esm.SubscribePredictionsEnd(sub.TargetTwitchID)
@@ -251,6 +259,21 @@ func (esm *EventsubManager) RefreshAllEventsubSubscriptions() {
 			esm.SubscribePredictionsLock(sub.TargetTwitchID)
 			time.Sleep(time.Millisecond * 100)
 		}
+		if sub.Type == helix.EventSubTypeChannelPollBegin || sub.Type == helix.EventSubTypeChannelPollProgress || sub.Type == helix.EventSubTypeChannelPollEnd {
+			_ = esm.RemoveEventSubSubscription(sub.SubscriptionID)
+			esm.subscribePollEvent(sub.TargetTwitchID, sub.Type)
+			time.Sleep(time.Millisecond * 100)
+		}
+		if sub.Type == helix.EventSubTypeStreamOnline || sub.Type == helix.EventSubTypeStreamOffline {
+			_ = esm.RemoveEventSubSubscription(sub.SubscriptionID)
+			esm.subscribeStreamEvent(sub.TargetTwitchID, sub.Type)
+			time.Sleep(time.Millisecond * 100)
+		}
+		if sub.Type == helix.EventSubTypeChannelRaid {
+			_ = esm.RemoveEventSubSubscription(sub.SubscriptionID)
+			esm.SubscribeRaids(sub.TargetTwitchID)
+			time.Sleep(time.Millisecond * 100)
+		}
 	}
 }
 
@@ -0,0 +1,113 @@
+package eventsubmanager
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/humanize"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/messages"
+	"github.com/nicklaw5/helix/v2"
+)
+
+func (esm *EventsubManager) SubscribePolls(userID string) {
+	esm.subscribePollEvent(userID, helix.EventSubTypeChannelPollBegin)
+	esm.subscribePollEvent(userID, helix.EventSubTypeChannelPollProgress)
+	esm.subscribePollEvent(userID, helix.EventSubTypeChannelPollEnd)
+}
+
+func (esm *EventsubManager) subscribePollEvent(userID string, subType string) {
+	response, err := esm.helixClient.CreateEventSubSubscription(userID, esm.cfg.WebhookApiBaseUrl+"/api/eventsub?type="+subType, subType)
+	if err != nil {
+		log.Errorf("Error subscribing: %s", err)
+		return
+	}
+
+	log.Infof("[%d] created subscription %s", response.StatusCode, response.ErrorMessage)
+	for _, sub := range response.Data.EventSubSubscriptions {
+		log.Infof("new sub in %s %s", userID, sub.Type)
+		esm.db.AddEventSubSubscription(userID, sub.ID, sub.Version, sub.Type, "")
+	}
+}
+
+func (esm *EventsubManager) HandlePollBegin(event []byte) {
+	var data helix.EventSubChannelPollBeginEvent
+	err := json.Unmarshal(event, &data)
+	if err != nil {
+		log.Errorf("Failed to decode event: %s", err)
+		return
+	}
+
+	log.Infof("pollBegin %s", data.StartedAt)
+	if data.ID == "" {
+		return
+	}
+
+	titles := []string{}
+	for _, choice := range data.Choices {
+		titles = append(titles, choice.Title)
+	}
+
+	esm.messenger.Say(data.BroadcasterUserID, data.BroadcasterUserLogin, messages.PollBegin, messages.Values{
+		"title":    data.Title,
+		"choices":  strings.Join(titles, " | "),
+		"duration": humanize.TimeUntil(data.StartedAt.Time, data.EndsAt.Time),
+	})
+}
+
+func (esm *EventsubManager) HandlePollProgress(event []byte) {
+	var data helix.EventSubChannelPollProgressEvent
+	err := json.Unmarshal(event, &data)
+	if err != nil {
+		log.Errorf("Failed to decode event: %s", err)
+		return
+	}
+
+	votes := 0
+	for _, choice := range data.Choices {
+		votes += choice.Votes
+	}
+	log.Debugf("pollProgress %s %s %d votes", data.BroadcasterUserLogin, data.ID, votes)
+}
+
+func (esm *EventsubManager) HandlePollEnd(event []byte) {
+	var data helix.EventSubChannelPollEndEvent
+	err := json.Unmarshal(event, &data)
+	if err != nil {
+		log.Errorf("Failed to decode event: %s", err)
+		return
+	}
+
+	log.Infof("pollEnd %s", data.Status)
+	if data.ID == "" {
+		return
+	}
+
+	// archived polls are hidden from viewers, so we don't announce them either
+	if strings.ToUpper(data.Status) == dto.PollStatusArchived {
+		return
+	}
+
+	var winner helix.PollChoice
+	votes := 0
+	for _, choice := range data.Choices {
+		votes += choice.Votes
+		if choice.Votes > winner.Votes {
+			winner = choice
+		}
+	}
+
+	if winner.ID == "" {
+		esm.messenger.Say(data.BroadcasterUserID, data.BroadcasterUserLogin, messages.PollEndWithout, messages.Values{"title": data.Title})
+		return
+	}
+
+	esm.messenger.Say(data.BroadcasterUserID, data.BroadcasterUserLogin, messages.PollEnd, messages.Values{
+		"title":  data.Title,
+		"winner": winner.Title,
+		"votes":  strconv.Itoa(winner.Votes),
+		"total":  strconv.Itoa(votes),
+	})
+}
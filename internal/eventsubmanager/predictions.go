@@ -2,21 +2,48 @@ package eventsubmanager
 
 import (
 	"encoding/json"
-	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gempir/gempbot/internal/dto"
 	"github.com/gempir/gempbot/internal/humanize"
 	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/messages"
+	"github.com/gempir/gempbot/internal/store"
 	"github.com/nicklaw5/helix/v2"
 )
 
 func (esm *EventsubManager) SubscribePredictions(userID string) {
 	esm.SubscribePredictionsBegin(userID)
+	esm.SubscribePredictionsProgress(userID)
 	esm.SubscribePredictionsLock(userID)
 	esm.SubscribePredictionsEnd(userID)
 }
 
+// SubscribeAllPredictionsProgress backfills channel.prediction.progress for channels that subscribed to predictions before progress was tracked
+func (esm *EventsubManager) SubscribeAllPredictionsProgress() {
+	subscribed := map[string]bool{}
+	hasProgress := map[string]bool{}
+	for _, sub := range esm.db.GetAllSubscriptions() {
+		switch sub.Type {
+		case helix.EventSubTypeChannelPredictionBegin, helix.EventSubTypeChannelPredictionLock, helix.EventSubTypeChannelPredictionEnd:
+			subscribed[sub.TargetTwitchID] = true
+		case helix.EventSubTypeChannelPredictionProgress:
+			hasProgress[sub.TargetTwitchID] = true
+		}
+	}
+
+	log.Infof("Checking prediction progress subscriptions of %d channels", len(subscribed))
+	for userID := range subscribed {
+		if hasProgress[userID] {
+			continue
+		}
+
+		esm.SubscribePredictionsProgress(userID)
+		time.Sleep(time.Millisecond * 100)
+	}
+}
+
 func (esm *EventsubManager) SubscribePredictionsBegin(userID string) {
 	response, err := esm.helixClient.CreateEventSubSubscription(userID, esm.cfg.WebhookApiBaseUrl+"/api/eventsub?type="+helix.EventSubTypeChannelPredictionBegin, "channel.prediction.begin")
 	if err != nil {
@@ -31,6 +58,20 @@ func (esm *EventsubManager) SubscribePredictionsBegin(userID string) {
 	}
 }
 
+func (esm *EventsubManager) SubscribePredictionsProgress(userID string) {
+	response, err := esm.helixClient.CreateEventSubSubscription(userID, esm.cfg.WebhookApiBaseUrl+"/api/eventsub?type="+helix.EventSubTypeChannelPredictionProgress, "channel.prediction.progress")
+	if err != nil {
+		log.Errorf("Error subscribing: %s", err)
+		return
+	}
+
+	log.Infof("[%d] created subscription %s", response.StatusCode, response.ErrorMessage)
+	for _, sub := range response.Data.EventSubSubscriptions {
+		log.Infof("new sub in %s %s", userID, sub.Type)
+		esm.db.AddEventSubSubscription(userID, sub.ID, sub.Version, sub.Type, "")
+	}
+}
+
 func (esm *EventsubManager) SubscribePredictionsLock(userID string) {
 	response, err := esm.helixClient.CreateEventSubSubscription(userID, esm.cfg.WebhookApiBaseUrl+"/api/eventsub?type="+helix.EventSubTypeChannelPredictionLock, "channel.prediction.lock")
 	if err != nil {
@@ -72,9 +113,16 @@ func (esm *EventsubManager) HandlePredictionBegin(event []byte) {
 		return
 	}
 
-	lockedTime := &data.LocksAt.Time
-	if lockedTime.IsZero() {
-		lockedTime = nil
+	err = esm.db.SavePrediction(store.PredictionLog{
+		ID:            data.ID,
+		OwnerTwitchID: data.BroadcasterUserID,
+		Title:         data.Title,
+		Status:        dto.PredictionStatusActive,
+		StartedAt:     data.StartedAt.Time,
+		Outcomes:      createOutcomeLogs(data.ID, data.Outcomes),
+	})
+	if err != nil {
+		log.Errorf("failed to save prediction %s: %s", data.ID, err)
 	}
 
 	titles := []string{}
@@ -82,14 +130,34 @@ func (esm *EventsubManager) HandlePredictionBegin(event []byte) {
 		titles = append(titles, outcome.Title)
 	}
 
-	esm.chatClient.Say(
-		data.BroadcasterUserLogin,
-		fmt.Sprintf("PogChamp prediction: %s [ %s ] ending in %s",
-			data.Title,
-			strings.Join(titles, " | "),
-			humanize.TimeUntil(data.StartedAt.Time, data.LocksAt.Time),
-		),
-	)
+	esm.messenger.Say(data.BroadcasterUserID, data.BroadcasterUserLogin, messages.PredictionBegin, messages.Values{
+		"title":    data.Title,
+		"outcomes": strings.Join(titles, " | "),
+		"duration": humanize.TimeUntil(data.StartedAt.Time, data.LocksAt.Time),
+	})
+}
+
+// HandlePredictionProgress updates the outcome totals and the top predictors while a prediction is running
+func (esm *EventsubManager) HandlePredictionProgress(event []byte) {
+	var data helix.EventSubChannelPredictionProgressEvent
+	err := json.Unmarshal(event, &data)
+	if err != nil {
+		log.Errorf("Failed to decode event: %s", err)
+		return
+	}
+
+	if data.ID == "" {
+		return
+	}
+
+	for _, outcome := range createOutcomeLogs(data.ID, data.Outcomes) {
+		err := esm.db.SaveOutcome(outcome)
+		if err != nil {
+			log.Errorf("failed to save outcome %s: %s", outcome.ID, err)
+		}
+	}
+
+	esm.saveTopPredictors(data.ID, data.Outcomes)
 }
 
 func (esm *EventsubManager) HandlePredictionLock(event []byte) {
@@ -110,12 +178,20 @@ func (esm *EventsubManager) HandlePredictionLock(event []byte) {
 		lockedTime = nil
 	}
 
-	esm.chatClient.Say(
-		data.BroadcasterUserLogin,
-		fmt.Sprintf("FBtouchdown locked submissions for: %s",
-			data.Title,
-		),
-	)
+	err = esm.db.SavePrediction(store.PredictionLog{
+		ID:            data.ID,
+		OwnerTwitchID: data.BroadcasterUserID,
+		Title:         data.Title,
+		Status:        dto.PredictionStatusLocked,
+		StartedAt:     data.StartedAt.Time,
+		LockedAt:      lockedTime,
+		Outcomes:      createOutcomeLogs(data.ID, data.Outcomes),
+	})
+	if err != nil {
+		log.Errorf("failed to save prediction %s: %s", data.ID, err)
+	}
+
+	esm.messenger.Say(data.BroadcasterUserID, data.BroadcasterUserLogin, messages.PredictionLock, messages.Values{"title": data.Title})
 }
 
 func (esm *EventsubManager) HandlePredictionEnd(event []byte) {
@@ -131,11 +207,33 @@ func (esm *EventsubManager) HandlePredictionEnd(event []byte) {
 		return
 	}
 
-	endTime := &data.EndedAt.Time
+	// helix decodes ended_at with a typo, so it is usually empty
+	endTime := data.EndedAt.Time
 	if endTime.IsZero() {
-		endTime = nil
+		endTime = time.Now()
+	}
+
+	var lockedTime *time.Time
+	if previous, err := esm.db.GetPrediction(data.ID); err == nil {
+		lockedTime = previous.LockedAt
 	}
 
+	err = esm.db.SavePrediction(store.PredictionLog{
+		ID:               data.ID,
+		OwnerTwitchID:    data.BroadcasterUserID,
+		Title:            data.Title,
+		WinningOutcomeID: data.WinningOutcomeID,
+		Status:           strings.ToUpper(data.Status),
+		StartedAt:        data.StartedAt.Time,
+		LockedAt:         lockedTime,
+		EndedAt:          &endTime,
+		Outcomes:         createOutcomeLogs(data.ID, data.Outcomes),
+	})
+	if err != nil {
+		log.Errorf("failed to save prediction %s: %s", data.ID, err)
+	}
+	esm.saveTopPredictors(data.ID, data.Outcomes)
+
 	var winningOutcome helix.EventSubOutcome
 
 	for _, outcome := range data.Outcomes {
@@ -145,21 +243,52 @@ func (esm *EventsubManager) HandlePredictionEnd(event []byte) {
 	}
 
 	if strings.ToUpper(data.Status) == dto.PredictionStatusCanceled {
-		esm.chatClient.Say(
-			data.BroadcasterUserLogin,
-			fmt.Sprintf("NinjaGrumpy canceled prediction: %s",
-				data.Title,
-			),
-		)
+		esm.messenger.Say(data.BroadcasterUserID, data.BroadcasterUserLogin, messages.PredictionCanceled, messages.Values{"title": data.Title})
 	} else {
-		esm.chatClient.Say(
-			data.BroadcasterUserLogin,
-			fmt.Sprintf("PogChamp ended prediction: %s Winner: %s %s",
-				data.Title,
-				getColorEmoji(winningOutcome),
-				winningOutcome.Title,
-			),
-		)
+		esm.messenger.Say(data.BroadcasterUserID, data.BroadcasterUserLogin, messages.PredictionEnd, messages.Values{
+			"title":  data.Title,
+			"color":  getColorEmoji(winningOutcome),
+			"winner": winningOutcome.Title,
+		})
+	}
+}
+
+func createOutcomeLogs(predictionID string, outcomes []helix.EventSubOutcome) []store.PredictionLogOutcome {
+	logs := []store.PredictionLogOutcome{}
+	for index, outcome := range outcomes {
+		logs = append(logs, store.PredictionLogOutcome{
+			ID:            outcome.ID,
+			PredictionID:  predictionID,
+			Position:      index,
+			Title:         outcome.Title,
+			Color:         strings.ToLower(outcome.Color),
+			Users:         outcome.Users,
+			ChannelPoints: outcome.ChannelPoints,
+		})
+	}
+
+	return logs
+}
+
+func (esm *EventsubManager) saveTopPredictors(predictionID string, outcomes []helix.EventSubOutcome) {
+	predictors := []store.PredictionLogPredictor{}
+	for _, outcome := range outcomes {
+		for _, predictor := range outcome.TopPredictors {
+			predictors = append(predictors, store.PredictionLogPredictor{
+				PredictionID:      predictionID,
+				UserID:            predictor.UserID,
+				UserLogin:         predictor.UserLogin,
+				UserName:          predictor.UserName,
+				OutcomeID:         outcome.ID,
+				ChannelPointsUsed: predictor.ChannelPointsUsed,
+				ChannelPointsWon:  predictor.ChannelPointWon,
+			})
+		}
+	}
+
+	err := esm.db.SavePredictors(predictors)
+	if err != nil {
+		log.Errorf("failed to save top predictors of %s: %s", predictionID, err)
 	}
 }
 
@@ -0,0 +1,55 @@
+package eventsubmanager
+
+import (
+	"encoding/json"
+
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/nicklaw5/helix/v2"
+)
+
+// RegisterRaidCallback is called for every raid into a subscribed channel
+func (esm *EventsubManager) RegisterRaidCallback(callback func(raid helix.EventSubChannelRaidEvent)) {
+	esm.raidCallback = callback
+}
+
+// SubscribeRaids subscribes to incoming raids unless the subscription already exists
+func (esm *EventsubManager) SubscribeRaids(userID string) {
+	if len(esm.db.GetAllRaidSubscriptions(userID)) > 0 {
+		return
+	}
+
+	response, err := esm.helixClient.CreateRaidEventSubSubscription(userID, esm.cfg.WebhookApiBaseUrl+"/api/eventsub?type="+helix.EventSubTypeChannelRaid)
+	if err != nil {
+		log.Errorf("Error subscribing: %s", err)
+		return
+	}
+
+	log.Infof("[%d] created subscription %s", response.StatusCode, response.ErrorMessage)
+	for _, sub := range response.Data.EventSubSubscriptions {
+		log.Infof("new sub in %s %s", userID, sub.Type)
+		esm.db.AddEventSubSubscription(userID, sub.ID, sub.Version, sub.Type, "")
+	}
+}
+
+func (esm *EventsubManager) UnsubscribeRaids(userID string) {
+	for _, sub := range esm.db.GetAllRaidSubscriptions(userID) {
+		err := esm.RemoveEventSubSubscription(sub.SubscriptionID)
+		if err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+func (esm *EventsubManager) HandleRaid(event []byte) {
+	var data helix.EventSubChannelRaidEvent
+	err := json.Unmarshal(event, &data)
+	if err != nil {
+		log.Errorf("Failed to decode event: %s", err)
+		return
+	}
+
+	log.Infof("raid %s -> %s with %d viewers", data.FromBroadcasterUserLogin, data.ToBroadcasterUserLogin, data.Viewers)
+	if esm.raidCallback != nil {
+		esm.raidCallback(data)
+	}
+}
@@ -0,0 +1,129 @@
+package eventsubmanager
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/nicklaw5/helix/v2"
+)
+
+// RegisterStreamStatusCallback is called whenever a subscribed channel goes live or offline
+func (esm *EventsubManager) RegisterStreamStatusCallback(callback func(channelID, channelLogin string, live bool)) {
+	esm.streamStatusCallback = callback
+}
+
+// SubscribeStreamStatus subscribes to stream.online and stream.offline, each unless it already exists
+func (esm *EventsubManager) SubscribeStreamStatus(userID string) {
+	for _, subType := range esm.missingStreamSubscriptions(userID) {
+		esm.subscribeStreamEvent(userID, subType)
+	}
+}
+
+// SubscribeAllStreamStatus backfills the stream status subscriptions of every channel the bot joins, e.g. channels joined before timers existed
+func (esm *EventsubManager) SubscribeAllStreamStatus() {
+	botConfigs := esm.db.GetAllJoinBotConfigs()
+
+	log.Infof("Checking stream status subscriptions of %d channels", len(botConfigs))
+	for _, botConfig := range botConfigs {
+		for _, subType := range esm.missingStreamSubscriptions(botConfig.OwnerTwitchID) {
+			esm.subscribeStreamEvent(botConfig.OwnerTwitchID, subType)
+			time.Sleep(time.Millisecond * 100)
+		}
+	}
+}
+
+// missingStreamSubscriptions checks stream.online and stream.offline separately, so a half created pair gets repaired
+func (esm *EventsubManager) missingStreamSubscriptions(userID string) []string {
+	subscribed := map[string]bool{}
+	for _, sub := range esm.db.GetAllStreamSubscriptions(userID) {
+		subscribed[sub.Type] = true
+	}
+
+	missing := []string{}
+	for _, subType := range []string{helix.EventSubTypeStreamOnline, helix.EventSubTypeStreamOffline} {
+		if !subscribed[subType] {
+			missing = append(missing, subType)
+		}
+	}
+
+	return missing
+}
+
+func (esm *EventsubManager) subscribeStreamEvent(userID string, subType string) {
+	response, err := esm.helixClient.CreateEventSubSubscription(userID, esm.cfg.WebhookApiBaseUrl+"/api/eventsub?type="+subType, subType)
+	if err != nil {
+		log.Errorf("Error subscribing: %s", err)
+		return
+	}
+
+	log.Infof("[%d] created subscription %s", response.StatusCode, response.ErrorMessage)
+	for _, sub := range response.Data.EventSubSubscriptions {
+		log.Infof("new sub in %s %s", userID, sub.Type)
+		esm.db.AddEventSubSubscription(userID, sub.ID, sub.Version, sub.Type, "")
+	}
+}
+
+func (esm *EventsubManager) HandleStreamOnline(event []byte) {
+	var data helix.EventSubStreamOnlineEvent
+	err := json.Unmarshal(event, &data)
+	if err != nil {
+		log.Errorf("Failed to decode event: %s", err)
+		return
+	}
+
+	log.Infof("streamOnline %s %s", data.BroadcasterUserLogin, data.Type)
+	// reruns and premieres also fire stream.online, only real live streams count
+	if data.Type != "" && data.Type != "live" {
+		return
+	}
+
+	session := store.StreamSession{
+		ChannelTwitchID: data.BroadcasterUserID,
+		StreamID:        data.ID,
+		StartedAt:       data.StartedAt.Time,
+	}
+	info, err := esm.helixClient.GetChannelInformation(data.BroadcasterUserID)
+	if err != nil {
+		log.Errorf("failed to get channel information %s: %s", data.BroadcasterUserID, err)
+	} else {
+		session.Title = info.Title
+		session.CategoryID = info.GameID
+		session.CategoryName = info.GameName
+	}
+
+	// a missed stream.offline would leave the previous session open forever
+	err = esm.db.EndStreamSession(context.Background(), data.BroadcasterUserID, data.StartedAt.Time)
+	if err != nil {
+		log.Error(err)
+	}
+	err = esm.db.StartStreamSession(context.Background(), session)
+	if err != nil {
+		log.Errorf("failed to save stream session %s: %s", data.ID, err)
+	}
+
+	if esm.streamStatusCallback != nil {
+		esm.streamStatusCallback(data.BroadcasterUserID, data.BroadcasterUserLogin, true)
+	}
+}
+
+func (esm *EventsubManager) HandleStreamOffline(event []byte) {
+	var data helix.EventSubStreamOfflineEvent
+	err := json.Unmarshal(event, &data)
+	if err != nil {
+		log.Errorf("Failed to decode event: %s", err)
+		return
+	}
+
+	log.Infof("streamOffline %s", data.BroadcasterUserLogin)
+	err = esm.db.EndStreamSession(context.Background(), data.BroadcasterUserID, time.Now())
+	if err != nil {
+		log.Errorf("failed to end stream session %s: %s", data.BroadcasterUserID, err)
+	}
+
+	if esm.streamStatusCallback != nil {
+		esm.streamStatusCallback(data.BroadcasterUserID, data.BroadcasterUserLogin, false)
+	}
+}
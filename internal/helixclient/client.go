@@ -19,6 +19,7 @@ type Client interface {
 	StartRefreshTokenRoutine()
 	RefreshToken(token store.UserAccessToken) error
 	CreateEventSubSubscription(userID string, webHookUrl string, subType string) (*helix.EventSubSubscriptionsResponse, error)
+	CreateRaidEventSubSubscription(userID string, webHookUrl string) (*helix.EventSubSubscriptionsResponse, error)
 	CreateRewardEventSubSubscription(userID, webHookUrl, subType, rewardID string, false bool) (*helix.EventSubSubscriptionsResponse, error)
 	RemoveEventSubSubscription(id string) (*helix.RemoveEventSubSubscriptionParamsResponse, error)
 	GetEventSubSubscriptions(params *helix.EventSubSubscriptionsParams) (*helix.EventSubSubscriptionsResponse, error)
@@ -26,6 +27,9 @@ type Client interface {
 	GetPredictions(params *helix.PredictionsParams) (*helix.PredictionsResponse, error)
 	EndPrediction(params *helix.EndPredictionParams) (*helix.PredictionsResponse, error)
 	CreatePrediction(params *helix.CreatePredictionParams) (*helix.PredictionsResponse, error)
+	GetPolls(params *helix.PollsParams) (*helix.PollsResponse, error)
+	EndPoll(params *helix.EndPollParams) (*helix.PollsResponse, error)
+	CreatePoll(params *helix.CreatePollParams) (*helix.PollsResponse, error)
 	CreateOrUpdateReward(userID string, reward CreateCustomRewardRequest, rewardID string) (*helix.ChannelCustomReward, error)
 	UpdateRedemptionStatus(broadcasterID, rewardID string, redemptionID string, statusSuccess bool) error
 	DeleteReward(userID string, rewardID string) error
@@ -33,6 +37,12 @@ type Client interface {
 	GetUsersByUsernames(usernames []string) (map[string]UserData, error)
 	GetUserByUsername(username string) (UserData, error)
 	GetUserByUserID(userID string) (UserData, error)
+	GetLiveUserIDs(userIDs []string) (map[string]bool, error)
+	GetChannelInformation(broadcasterID string) (helix.ChannelInformation, error)
+	BanUser(broadcasterID string, userID string, duration int, reason string) error
+	DeleteChatMessage(broadcasterID string, messageID string) error
+	SendShoutout(broadcasterID string, toBroadcasterID string) error
+	SendChatAnnouncement(broadcasterID string, message string) error
 	SetUserAccessToken(token string)
 	ValidateToken(accessToken string) (bool, *helix.ValidateTokenResponse, error)
 	RequestUserAccessToken(code string) (*helix.UserAccessTokenResponse, error)
@@ -48,6 +58,12 @@ type HelixClient struct {
 	db              store.Store
 	httpClient      *http.Client
 	refreshTtlCache *ttlcache.Cache
+	dryRun          dryRun
+}
+
+type dryRun interface {
+	Enabled(channelID string) bool
+	Record(channelID string, action string, details string)
 }
 
 var (
@@ -64,7 +80,7 @@ func init() {
 
 const TWITCH_API = "https://api.twitch.tv/"
 
-var scopes = []string{"channel:read:redemptions", "channel:manage:redemptions", "channel:read:predictions", "channel:manage:predictions moderation:read"}
+var scopes = []string{"channel:read:redemptions", "channel:manage:redemptions", "channel:read:predictions", "channel:manage:predictions moderation:read", "channel:read:polls", "channel:manage:polls", "moderator:manage:banned_users", "moderator:manage:chat_messages", "moderator:manage:shoutouts", "moderator:manage:announcements"}
 
 // NewClient Create helix client
 func NewClient(cfg *config.Config, db store.Store) *HelixClient {
@@ -95,6 +111,15 @@ func NewClient(cfg *config.Config, db store.Store) *HelixClient {
 	}
 }
 
+// SetDryRun skips redemption updates and prediction changes in dry-run channels
+func (c *HelixClient) SetDryRun(dryRun dryRun) {
+	c.dryRun = dryRun
+}
+
+func (c *HelixClient) isDryRun(channelID string) bool {
+	return c.dryRun != nil && c.dryRun.Enabled(channelID)
+}
+
 // StartRefreshTokenRoutine refresh our token
 func (c *HelixClient) StartRefreshTokenRoutine() {
 	setOrUpdateAccessToken(c.Client, c.db)
@@ -55,6 +55,31 @@ func (c *HelixClient) CreateRewardEventSubSubscription(userID, webHookUrl, subTy
 	return response, err
 }
 
+// CreateRaidEventSubSubscription subscribes to raids into the channel, the condition differs from other subscriptions
+func (c *HelixClient) CreateRaidEventSubSubscription(userID string, webHookUrl string) (*helix.EventSubSubscriptionsResponse, error) {
+	c.Client.SetAppAccessToken(c.AppAccessToken.AccessToken)
+	c.Client.SetUserAccessToken("")
+	response, err := c.Client.CreateEventSubSubscription(
+		&helix.EventSubSubscription{
+			Condition: helix.EventSubCondition{ToBroadcasterUserID: userID},
+			Transport: helix.EventSubTransport{Method: "webhook", Callback: webHookUrl, Secret: c.eventSubSecret},
+			Type:      helix.EventSubTypeChannelRaid,
+			Version:   "1",
+		},
+	)
+	if err != nil {
+		return response, err
+	}
+	if response.StatusCode == http.StatusUnauthorized {
+		err := c.refreshUserAccessToken(userID)
+		if err == nil {
+			return c.CreateRaidEventSubSubscription(userID, webHookUrl)
+		}
+	}
+
+	return response, err
+}
+
 func (c *HelixClient) GetAllSubscriptions(eventType string) []helix.EventSubSubscription {
 	subs := []helix.EventSubSubscription{}
 
@@ -0,0 +1,150 @@
+package helixclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/nicklaw5/helix/v2"
+)
+
+type BanUserRequest struct {
+	Data helix.BanUserRequestBody `json:"data"`
+}
+
+// BanUser bans or, with a duration in seconds, times out the user as the broadcaster
+func (c *HelixClient) BanUser(broadcasterID string, userID string, duration int, reason string) error {
+	if c.isDryRun(broadcasterID) {
+		c.dryRun.Record(broadcasterID, dto.DryRunActionBanUser, fmt.Sprintf("user: %s duration: %ds reason: %s", userID, duration, reason))
+		return nil
+	}
+
+	token, err := c.db.GetUserAccessToken(broadcasterID)
+	if err != nil {
+		return fmt.Errorf("bot has no access token, broadcaster must login")
+	}
+
+	marshalled, err := json.Marshal(BanUserRequest{Data: helix.BanUserRequestBody{
+		Duration: duration,
+		Reason:   reason,
+		UserId:   userID,
+	}})
+	if err != nil {
+		return err
+	}
+
+	method := http.MethodPost
+	reqUrl, err := url.Parse(TWITCH_API + "helix/moderation/bans")
+	if err != nil {
+		return err
+	}
+
+	query := reqUrl.Query()
+	query.Set("broadcaster_id", broadcasterID)
+	query.Set("moderator_id", broadcasterID)
+
+	reqUrl.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(method, reqUrl.String(), bytes.NewBuffer(marshalled))
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	req.Header.Set("authorization", "Bearer "+token.AccessToken)
+	req.Header.Set("client-id", c.clientID)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not ban user: %s", err)
+	}
+	defer resp.Body.Close()
+
+	log.Infof("[%d] BanUser %s in %s for %d", resp.StatusCode, userID, broadcasterID, duration)
+	if resp.StatusCode == http.StatusUnauthorized {
+		err := c.refreshUserAccessToken(broadcasterID)
+		if err == nil {
+			return c.BanUser(broadcasterID, userID, duration, reason)
+		}
+
+		return fmt.Errorf("bot failed to moderate, broadcaster must login")
+	}
+
+	if resp.StatusCode >= 400 {
+		var response ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal ban error response: %s", err.Error())
+		}
+
+		return fmt.Errorf("bad twitch api response: %s", response.Message)
+	}
+
+	return nil
+}
+
+// DeleteChatMessage removes a single chat message as the broadcaster
+func (c *HelixClient) DeleteChatMessage(broadcasterID string, messageID string) error {
+	if c.isDryRun(broadcasterID) {
+		c.dryRun.Record(broadcasterID, dto.DryRunActionDeleteChatMessage, "message: "+messageID)
+		return nil
+	}
+
+	token, err := c.db.GetUserAccessToken(broadcasterID)
+	if err != nil {
+		return fmt.Errorf("bot has no access token, broadcaster must login")
+	}
+
+	method := http.MethodDelete
+	reqUrl, err := url.Parse(TWITCH_API + "helix/moderation/chat")
+	if err != nil {
+		return err
+	}
+
+	query := reqUrl.Query()
+	query.Set("broadcaster_id", broadcasterID)
+	query.Set("moderator_id", broadcasterID)
+	query.Set("message_id", messageID)
+
+	reqUrl.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(method, reqUrl.String(), nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	req.Header.Set("authorization", "Bearer "+token.AccessToken)
+	req.Header.Set("client-id", c.clientID)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	log.Infof("[%d][%s] %s", resp.StatusCode, method, reqUrl.String())
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		err := c.refreshUserAccessToken(broadcasterID)
+		if err == nil {
+			return c.DeleteChatMessage(broadcasterID, messageID)
+		}
+	}
+
+	if resp.StatusCode >= 400 {
+		var response ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal delete message error response: %s", err.Error())
+		}
+
+		return fmt.Errorf("failed to delete message: %s", response.Message)
+	}
+
+	return nil
+}
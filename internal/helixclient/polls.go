@@ -0,0 +1,114 @@
+package helixclient
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/nicklaw5/helix/v2"
+)
+
+func (c *HelixClient) GetPolls(params *helix.PollsParams) (*helix.PollsResponse, error) {
+	token, err := c.db.GetUserAccessToken(params.BroadcasterID)
+	if err != nil {
+		return &helix.PollsResponse{}, fmt.Errorf("bot has no access token, broadcaster must login")
+	}
+
+	c.Client.SetUserAccessToken(token.AccessToken)
+	resp, err := c.Client.GetPolls(params)
+	c.Client.SetUserAccessToken("")
+	if err != nil {
+		return &helix.PollsResponse{}, fmt.Errorf("could not get polls: %s", err)
+	}
+	log.Infof("[%d] GetPolls", resp.StatusCode)
+	if resp.StatusCode == http.StatusUnauthorized {
+		err := c.refreshUserAccessToken(params.BroadcasterID)
+		if err == nil {
+			return c.GetPolls(params)
+		}
+
+		return resp, fmt.Errorf("bot failed to manage polls, broadcaster must login %s", resp.ErrorMessage)
+	}
+	if len(resp.Data.Polls) < 1 {
+		return resp, fmt.Errorf("no poll found")
+	}
+
+	return resp, nil
+}
+
+func (c *HelixClient) EndPoll(params *helix.EndPollParams) (*helix.PollsResponse, error) {
+	if c.isDryRun(params.BroadcasterID) {
+		c.dryRun.Record(params.BroadcasterID, dto.DryRunActionEndPoll, fmt.Sprintf("id: %s status: %s", params.ID, params.Status))
+		return dryRunPollsResponse(), nil
+	}
+
+	token, err := c.db.GetUserAccessToken(params.BroadcasterID)
+	if err != nil {
+		return &helix.PollsResponse{}, fmt.Errorf("bot has no access token, broadcaster must login")
+	}
+
+	c.Client.SetUserAccessToken(token.AccessToken)
+	resp, err := c.Client.EndPoll(params)
+	c.Client.SetUserAccessToken("")
+	if err != nil {
+		return &helix.PollsResponse{}, fmt.Errorf("could not end poll: %s", err)
+	}
+	log.Infof("[%d] EndPoll", resp.StatusCode)
+	if resp.StatusCode == http.StatusUnauthorized {
+		err := c.refreshUserAccessToken(params.BroadcasterID)
+		if err == nil {
+			return c.EndPoll(params)
+		}
+
+		return resp, fmt.Errorf("bot failed to manage polls, broadcaster must login %s", resp.ErrorMessage)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return resp, fmt.Errorf("bad twitch api response %s", resp.ErrorMessage)
+	}
+
+	return resp, nil
+}
+
+func (c *HelixClient) CreatePoll(params *helix.CreatePollParams) (*helix.PollsResponse, error) {
+	if c.isDryRun(params.BroadcasterID) {
+		choices := []string{}
+		for _, choice := range params.Choices {
+			choices = append(choices, choice.Title)
+		}
+		c.dryRun.Record(params.BroadcasterID, dto.DryRunActionCreatePoll, fmt.Sprintf("title: %s choices: %s duration: %ds", params.Title, strings.Join(choices, ", "), params.Duration))
+		return dryRunPollsResponse(), nil
+	}
+
+	token, err := c.db.GetUserAccessToken(params.BroadcasterID)
+	if err != nil {
+		return &helix.PollsResponse{}, fmt.Errorf("bot has no access token, broadcaster must login")
+	}
+
+	c.Client.SetUserAccessToken(token.AccessToken)
+	resp, err := c.Client.CreatePoll(params)
+	c.Client.SetUserAccessToken("")
+	if err != nil {
+		return &helix.PollsResponse{}, fmt.Errorf("could not create poll: %s", err)
+	}
+	log.Infof("[%d] CreatePoll", resp.StatusCode)
+	if resp.StatusCode == http.StatusUnauthorized {
+		err := c.refreshUserAccessToken(params.BroadcasterID)
+		if err == nil {
+			return c.CreatePoll(params)
+		}
+
+		return resp, fmt.Errorf("bot failed to manage polls, broadcaster must login %s", resp.ErrorMessage)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return resp, fmt.Errorf("bad twitch api response: %s", resp.ErrorMessage)
+	}
+
+	return resp, nil
+}
+
+// dryRunPollsResponse looks like a successful response without polls
+func dryRunPollsResponse() *helix.PollsResponse {
+	return &helix.PollsResponse{ResponseCommon: helix.ResponseCommon{StatusCode: http.StatusOK}}
+}
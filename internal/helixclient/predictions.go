@@ -3,7 +3,9 @@ package helixclient
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/gempir/gempbot/internal/dto"
 	"github.com/gempir/gempbot/internal/log"
 	"github.com/nicklaw5/helix/v2"
 )
@@ -37,6 +39,11 @@ func (c *HelixClient) GetPredictions(params *helix.PredictionsParams) (*helix.Pr
 }
 
 func (c *HelixClient) EndPrediction(params *helix.EndPredictionParams) (*helix.PredictionsResponse, error) {
+	if c.isDryRun(params.BroadcasterID) {
+		c.dryRun.Record(params.BroadcasterID, dto.DryRunActionEndPrediction, fmt.Sprintf("id: %s status: %s winning outcome: %s", params.ID, params.Status, params.WinningOutcomeID))
+		return dryRunPredictionsResponse(), nil
+	}
+
 	token, err := c.db.GetUserAccessToken(params.BroadcasterID)
 	if err != nil {
 		return &helix.PredictionsResponse{}, fmt.Errorf("bot has no access token, broadcaster must login")
@@ -65,6 +72,15 @@ func (c *HelixClient) EndPrediction(params *helix.EndPredictionParams) (*helix.P
 }
 
 func (c *HelixClient) CreatePrediction(params *helix.CreatePredictionParams) (*helix.PredictionsResponse, error) {
+	if c.isDryRun(params.BroadcasterID) {
+		outcomes := []string{}
+		for _, outcome := range params.Outcomes {
+			outcomes = append(outcomes, outcome.Title)
+		}
+		c.dryRun.Record(params.BroadcasterID, dto.DryRunActionCreatePrediction, fmt.Sprintf("title: %s outcomes: %s window: %ds", params.Title, strings.Join(outcomes, ", "), params.PredictionWindow))
+		return dryRunPredictionsResponse(), nil
+	}
+
 	token, err := c.db.GetUserAccessToken(params.BroadcasterID)
 	if err != nil {
 		return &helix.PredictionsResponse{}, fmt.Errorf("bot has no access token, broadcaster must login")
@@ -91,3 +107,8 @@ func (c *HelixClient) CreatePrediction(params *helix.CreatePredictionParams) (*h
 
 	return resp, nil
 }
+
+// dryRunPredictionsResponse looks like a successful response without predictions
+func dryRunPredictionsResponse() *helix.PredictionsResponse {
+	return &helix.PredictionsResponse{ResponseCommon: helix.ResponseCommon{StatusCode: http.StatusOK}}
+}
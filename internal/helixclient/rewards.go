@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gempir/gempbot/internal/dto"
 	"github.com/gempir/gempbot/internal/log"
 	"github.com/nicklaw5/helix/v2"
 )
@@ -169,6 +170,11 @@ type UpdateRedemptionStatusResponse struct {
 }
 
 func (c *HelixClient) UpdateRedemptionStatus(broadcasterID, rewardID string, redemptionID string, statusSuccess bool) error {
+	if c.isDryRun(broadcasterID) {
+		c.dryRun.Record(broadcasterID, dto.DryRunActionUpdateRedemptionStatus, fmt.Sprintf("reward: %s redemption: %s success: %t", rewardID, redemptionID, statusSuccess))
+		return nil
+	}
+
 	token, err := c.db.GetUserAccessToken(broadcasterID)
 	if err != nil {
 		return fmt.Errorf("failed to get userAccess token to update redemption status for %s", broadcasterID)
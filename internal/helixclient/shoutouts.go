@@ -0,0 +1,112 @@
+package helixclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/nicklaw5/helix/v2"
+)
+
+// SendShoutout sends an official Twitch shoutout as the broadcaster
+func (c *HelixClient) SendShoutout(broadcasterID string, toBroadcasterID string) error {
+	if c.isDryRun(broadcasterID) {
+		c.dryRun.Record(broadcasterID, dto.DryRunActionShoutout, "to: "+toBroadcasterID)
+		return nil
+	}
+
+	token, err := c.db.GetUserAccessToken(broadcasterID)
+	if err != nil {
+		return fmt.Errorf("bot has no access token, broadcaster must login")
+	}
+
+	method := http.MethodPost
+	reqUrl, err := url.Parse(TWITCH_API + "helix/chat/shoutouts")
+	if err != nil {
+		return err
+	}
+
+	query := reqUrl.Query()
+	query.Set("from_broadcaster_id", broadcasterID)
+	query.Set("to_broadcaster_id", toBroadcasterID)
+	query.Set("moderator_id", broadcasterID)
+
+	reqUrl.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(method, reqUrl.String(), nil)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	req.Header.Set("authorization", "Bearer "+token.AccessToken)
+	req.Header.Set("client-id", c.clientID)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	log.Infof("[%d][%s] %s", resp.StatusCode, method, reqUrl.String())
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		err := c.refreshUserAccessToken(broadcasterID)
+		if err == nil {
+			return c.SendShoutout(broadcasterID, toBroadcasterID)
+		}
+	}
+
+	if resp.StatusCode >= 400 {
+		var response ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal shoutout error response: %s", err.Error())
+		}
+
+		return fmt.Errorf("failed to shoutout: %s", response.Message)
+	}
+
+	return nil
+}
+
+// SendChatAnnouncement posts a highlighted announcement as the broadcaster
+func (c *HelixClient) SendChatAnnouncement(broadcasterID string, message string) error {
+	if c.isDryRun(broadcasterID) {
+		c.dryRun.Record(broadcasterID, dto.DryRunActionAnnouncement, message)
+		return nil
+	}
+
+	token, err := c.db.GetUserAccessToken(broadcasterID)
+	if err != nil {
+		return fmt.Errorf("bot has no access token, broadcaster must login")
+	}
+
+	c.Client.SetUserAccessToken(token.AccessToken)
+	resp, err := c.Client.SendChatAnnouncement(&helix.SendChatAnnouncementParams{
+		BroadcasterID: broadcasterID,
+		ModeratorID:   broadcasterID,
+		Message:       message,
+	})
+	c.Client.SetUserAccessToken("")
+	if err != nil {
+		return fmt.Errorf("could not send announcement: %s", err)
+	}
+	log.Infof("[%d] SendChatAnnouncement %s", resp.StatusCode, broadcasterID)
+	if resp.StatusCode == http.StatusUnauthorized {
+		err := c.refreshUserAccessToken(broadcasterID)
+		if err == nil {
+			return c.SendChatAnnouncement(broadcasterID, message)
+		}
+
+		return fmt.Errorf("bot failed to send announcement, broadcaster must login %s", resp.ErrorMessage)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("bad twitch api response: %s", resp.ErrorMessage)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package helixclient
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/nicklaw5/helix/v2"
+)
+
+// GetLiveUserIDs returns which of the given users are currently live
+func (c *HelixClient) GetLiveUserIDs(userIDs []string) (map[string]bool, error) {
+	live := map[string]bool{}
+
+	for _, chunk := range chunkBy(userIDs, 100) {
+		if len(chunk) == 0 {
+			continue
+		}
+
+		resp, err := c.Client.GetStreams(&helix.StreamsParams{UserIDs: chunk, First: len(chunk)})
+		if err != nil {
+			return live, err
+		}
+		log.Infof("[%d] GetStreams", resp.StatusCode)
+		if resp.StatusCode != http.StatusOK {
+			return live, fmt.Errorf("bad helix response: %v", resp.ErrorMessage)
+		}
+
+		for _, stream := range resp.Data.Streams {
+			live[stream.UserID] = true
+		}
+	}
+
+	return live, nil
+}
+
+func (c *HelixClient) GetChannelInformation(broadcasterID string) (helix.ChannelInformation, error) {
+	resp, err := c.Client.GetChannelInformation(&helix.GetChannelInformationParams{BroadcasterIDs: []string{broadcasterID}})
+	if err != nil {
+		return helix.ChannelInformation{}, err
+	}
+	log.Infof("[%d] GetChannelInformation %s", resp.StatusCode, broadcasterID)
+	if resp.StatusCode != http.StatusOK {
+		return helix.ChannelInformation{}, fmt.Errorf("bad helix response: %v", resp.ErrorMessage)
+	}
+	if len(resp.Data.Channels) < 1 {
+		return helix.ChannelInformation{}, fmt.Errorf("no channel found")
+	}
+
+	return resp.Data.Channels[0], nil
+}
@@ -25,6 +25,10 @@ func (m *MockHelixClient) CreateRewardEventSubSubscription(userID, webHookUrl, s
 	return nil, nil
 }
 
+func (m *MockHelixClient) CreateRaidEventSubSubscription(userID string, webHookUrl string) (*helix.EventSubSubscriptionsResponse, error) {
+	return nil, nil
+}
+
 func (m *MockHelixClient) RemoveEventSubSubscription(id string) (*helix.RemoveEventSubSubscriptionParamsResponse, error) {
 	return nil, nil
 }
@@ -49,6 +53,18 @@ func (m *MockHelixClient) CreatePrediction(params *helix.CreatePredictionParams)
 	return nil, nil
 }
 
+func (m *MockHelixClient) GetPolls(params *helix.PollsParams) (*helix.PollsResponse, error) {
+	return nil, nil
+}
+
+func (m *MockHelixClient) EndPoll(params *helix.EndPollParams) (*helix.PollsResponse, error) {
+	return nil, nil
+}
+
+func (m *MockHelixClient) CreatePoll(params *helix.CreatePollParams) (*helix.PollsResponse, error) {
+	return nil, nil
+}
+
 func (m *MockHelixClient) CreateOrUpdateReward(userID string, reward CreateCustomRewardRequest, rewardID string) (*helix.ChannelCustomReward, error) {
 	return &helix.ChannelCustomReward{}, nil
 }
@@ -80,6 +96,30 @@ func (m *MockHelixClient) GetUserByUserID(userID string) (UserData, error) {
 	return UserData{}, nil
 }
 
+func (m *MockHelixClient) GetLiveUserIDs(userIDs []string) (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
+
+func (m *MockHelixClient) GetChannelInformation(broadcasterID string) (helix.ChannelInformation, error) {
+	return helix.ChannelInformation{BroadcasterID: broadcasterID}, nil
+}
+
+func (m *MockHelixClient) BanUser(broadcasterID string, userID string, duration int, reason string) error {
+	return nil
+}
+
+func (m *MockHelixClient) DeleteChatMessage(broadcasterID string, messageID string) error {
+	return nil
+}
+
+func (m *MockHelixClient) SendShoutout(broadcasterID string, toBroadcasterID string) error {
+	return nil
+}
+
+func (m *MockHelixClient) SendChatAnnouncement(broadcasterID string, message string) error {
+	return nil
+}
+
 func (m *MockHelixClient) SetUserAccessToken(token string) {}
 
 func (m *MockHelixClient) ValidateToken(accessToken string) (bool, *helix.ValidateTokenResponse, error) {
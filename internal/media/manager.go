@@ -7,6 +7,7 @@ import (
 	"github.com/gempir/gempbot/internal/dto"
 	"github.com/gempir/gempbot/internal/helixclient"
 	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/messages"
 	"github.com/gempir/gempbot/internal/store"
 	"github.com/google/uuid"
 	"github.com/puzpuzpuz/xsync"
@@ -40,12 +41,20 @@ type MediaManager struct {
 	rooms                     *xsync.MapOf[string, *Room]
 	connections               *xsync.MapOf[string, *Connection]
 	bot                       mediaBot
+	messenger                 *messages.Messenger
 	commandsActivatedChannels map[string]bool
 }
 
 type Connection struct {
 	id     string
 	writer func(message []byte)
+	// controls caches per channel login whether the user may control its media
+	controls *xsync.MapOf[string, controlledChannel]
+}
+
+type controlledChannel struct {
+	channelID string
+	allowed   bool
 }
 
 type Room struct {
@@ -62,15 +71,15 @@ type storage interface {
 	AddToQueue(queueItem store.MediaQueue) error
 	GetQueue(channelTwitchId string) []store.MediaQueue
 	GetAllMediaCommandsBotConfig() []store.BotConfig
+	GetChannelUserPermissions(userID string, channelID string) store.Permission
 }
 
 type mediaBot interface {
-	RegisterCommand(command string, handler func(dto.CommandPayload))
-	Say(channel string, message string)
+	RegisterCommand(command dto.Command)
 	Reply(channel string, parentMsgId, message string)
 }
 
-func NewMediaManager(storage storage, helixClient helixclient.Client, bot mediaBot) *MediaManager {
+func NewMediaManager(storage storage, helixClient helixclient.Client, bot mediaBot, messenger *messages.Messenger) *MediaManager {
 
 	commandsActivatedChannels := make(map[string]bool)
 	commandActivatedCfgs := storage.GetAllMediaCommandsBotConfig()
@@ -82,9 +91,15 @@ func NewMediaManager(storage storage, helixClient helixclient.Client, bot mediaB
 		connections:               xsync.NewMapOf[*Connection](),
 		commandsActivatedChannels: commandsActivatedChannels,
 		bot:                       bot,
+		messenger:                 messenger,
 	}
 
-	bot.RegisterCommand("sr", mm.handleSongRequest)
+	bot.RegisterCommand(dto.Command{
+		Name:        "sr",
+		Description: "Adds a youtube video to the media queue",
+		Usage:       "sr <youtube link>",
+		Handler:     mm.handleSongRequest,
+	})
 
 	for _, cfg := range commandActivatedCfgs {
 		if cfg.MediaCommands {
@@ -115,7 +130,9 @@ func (m *MediaManager) handleSongRequest(payload dto.CommandPayload) {
 	}
 
 	if !YOUTUBE_REGEX.MatchString(payload.Query) {
-		m.bot.Reply(payload.Msg.Channel, payload.Msg.ID, "invalid youtube url")
+		if message, ok := m.messenger.Render(payload.Msg.RoomID, messages.MediaInvalidUrl, messages.Values{"user": payload.Msg.User.DisplayName}); ok {
+			m.bot.Reply(payload.Msg.Channel, payload.Msg.ID, message)
+		}
 		return
 	}
 
@@ -171,13 +188,20 @@ type QueueStateMessage struct {
 	Queue  []store.MediaQueue `json:"queue"`
 }
 
-func (m *MediaManager) HandlePlayerState(connectionId string, userID string, state PlayerState, url string, time float32) {
+// HandlePlayerState controls the room of channel, the own room when empty. Other rooms need the media capability.
+func (m *MediaManager) HandlePlayerState(connectionId string, userID string, channel string, state PlayerState, url string, time float32) {
 	if userID == "" {
 		log.Errorf("missing userID time %f on connection %s", time, connectionId)
 		return
 	}
 
-	roomState := m.getRoom(userID)
+	channelID, ok := m.controlledChannel(connectionId, userID, channel)
+	if !ok {
+		log.Debugf("user %s can't control the media of %s", userID, channel)
+		return
+	}
+
+	roomState := m.getRoom(channelID)
 
 	roomState.Time = time
 	roomState.Url = url
@@ -200,6 +224,34 @@ func (m *MediaManager) HandlePlayerState(connectionId string, userID string, sta
 	}
 }
 
+func (m *MediaManager) controlledChannel(connectionId string, userID string, channel string) (string, bool) {
+	if channel == "" {
+		return userID, true
+	}
+
+	connection, ok := m.connections.Load(connectionId)
+	if ok {
+		if controlled, ok := connection.controls.Load(channel); ok {
+			return controlled.channelID, controlled.allowed
+		}
+	}
+
+	res, err := m.helixClient.GetUserByUsername(channel)
+	if err != nil {
+		return "", false
+	}
+
+	controlled := controlledChannel{channelID: res.ID, allowed: res.ID == userID}
+	if !controlled.allowed {
+		controlled.allowed = m.storage.GetChannelUserPermissions(userID, res.ID).Has(dto.CapabilityMedia)
+	}
+	if ok {
+		connection.controls.Store(channel, controlled)
+	}
+
+	return controlled.channelID, controlled.allowed
+}
+
 func (m *MediaManager) HandleGetQueue(connectionId string, userID string, channel string) {
 	var channelId string
 	if channel == "" {
@@ -237,7 +289,7 @@ func (m *MediaManager) getRoom(channelId string) *Room {
 func (m *MediaManager) RegisterConnection(userID string, writeFunc func(message []byte)) string {
 	connectionId := uuid.NewString()
 
-	m.connections.Store(connectionId, &Connection{writer: writeFunc, id: connectionId})
+	m.connections.Store(connectionId, &Connection{writer: writeFunc, id: connectionId, controls: xsync.NewMapOf[controlledChannel]()})
 
 	return connectionId
 }
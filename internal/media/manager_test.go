@@ -4,13 +4,15 @@ import (
 	"testing"
 
 	"github.com/gempir/gempbot/internal/bot"
+	"github.com/gempir/gempbot/internal/dto"
 	"github.com/gempir/gempbot/internal/helixclient"
+	"github.com/gempir/gempbot/internal/messages"
 	"github.com/gempir/gempbot/internal/store"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestCanRegisterConnectionAndHandleJoin(t *testing.T) {
-	mgr := NewMediaManager(store.NewMockStore(), helixclient.NewMockClient(), bot.NewMockbot())
+	mgr := NewMediaManager(store.NewMockStore(), helixclient.NewMockClient(), bot.NewMockbot(), messages.NewMessenger(store.NewMockStore(), bot.NewMockbot().Say))
 
 	connId := mgr.RegisterConnection("conn1", func(message []byte) {})
 	mgr.HandleJoin(connId, "userId1", "")
@@ -25,7 +27,7 @@ func TestCanRegisterConnectionAndHandleJoin(t *testing.T) {
 }
 
 func TestAbortsJoinWhenNoConnectionFound(t *testing.T) {
-	mgr := NewMediaManager(store.NewMockStore(), helixclient.NewMockClient(), bot.NewMockbot())
+	mgr := NewMediaManager(store.NewMockStore(), helixclient.NewMockClient(), bot.NewMockbot(), messages.NewMessenger(store.NewMockStore(), bot.NewMockbot().Say))
 
 	mgr.HandleJoin("conn1", "userId1", "channel")
 
@@ -34,14 +36,14 @@ func TestAbortsJoinWhenNoConnectionFound(t *testing.T) {
 }
 
 func TestCanCreateRoom(t *testing.T) {
-	mgr := NewMediaManager(store.NewMockStore(), helixclient.NewMockClient(), bot.NewMockbot())
+	mgr := NewMediaManager(store.NewMockStore(), helixclient.NewMockClient(), bot.NewMockbot(), messages.NewMessenger(store.NewMockStore(), bot.NewMockbot().Say))
 
 	_ = mgr.getRoom("userId1")
 	assert.Equal(t, 1, mgr.rooms.Size())
 }
 
 func TestCanGetExistingRoom(t *testing.T) {
-	mgr := NewMediaManager(store.NewMockStore(), helixclient.NewMockClient(), bot.NewMockbot())
+	mgr := NewMediaManager(store.NewMockStore(), helixclient.NewMockClient(), bot.NewMockbot(), messages.NewMessenger(store.NewMockStore(), bot.NewMockbot().Say))
 
 	room := mgr.getRoom("userId1")
 	room.Time = 10
@@ -52,12 +54,38 @@ func TestCanGetExistingRoom(t *testing.T) {
 }
 
 func TestCanHandlePlayerStateChange(t *testing.T) {
-	mgr := NewMediaManager(store.NewMockStore(), helixclient.NewMockClient(), bot.NewMockbot())
+	mgr := NewMediaManager(store.NewMockStore(), helixclient.NewMockClient(), bot.NewMockbot(), messages.NewMessenger(store.NewMockStore(), bot.NewMockbot().Say))
 
 	connId := mgr.RegisterConnection("conn1", func(message []byte) {})
 	mgr.HandleJoin(connId, "userId1", "")
 
-	mgr.HandlePlayerState("conn1", "userId1", PLAYING, "videoId1", 10)
+	mgr.HandlePlayerState("conn1", "userId1", "", PLAYING, "videoId1", 10)
 	room := mgr.getRoom("userId1")
 	assert.Equal(t, float32(10), room.Time)
 }
+
+func TestPlayerStateOfOtherChannelNeedsMediaCapability(t *testing.T) {
+	db := store.NewMockStore()
+	mgr := NewMediaManager(db, helixclient.NewMockClient(), bot.NewMockbot(), messages.NewMessenger(db, bot.NewMockbot().Say))
+
+	mgr.HandlePlayerState("conn1", "userId1", "testusergempir", PLAYING, "videoId1", 10)
+	assert.Equal(t, float32(0), mgr.getRoom("123").Time)
+
+	db.Permissions = []store.Permission{{ChannelTwitchId: "123", TwitchID: "userId1", Capabilities: dto.CapabilityMedia}}
+	mgr.HandlePlayerState("conn1", "userId1", "testusergempir", PLAYING, "videoId1", 10)
+	assert.Equal(t, float32(10), mgr.getRoom("123").Time)
+}
+
+func TestPlayerStatePermissionIsResolvedOncePerConnection(t *testing.T) {
+	db := store.NewMockStore()
+	mgr := NewMediaManager(db, helixclient.NewMockClient(), bot.NewMockbot(), messages.NewMessenger(db, bot.NewMockbot().Say))
+	connId := mgr.RegisterConnection("userId1", func(message []byte) {})
+
+	db.Permissions = []store.Permission{{ChannelTwitchId: "123", TwitchID: "userId1", Capabilities: dto.CapabilityMedia}}
+	mgr.HandlePlayerState(connId, "userId1", "testusergempir", PLAYING, "videoId1", 10)
+	assert.Equal(t, float32(10), mgr.getRoom("123").Time)
+
+	db.Permissions = nil
+	mgr.HandlePlayerState(connId, "userId1", "testusergempir", PLAYING, "videoId1", 20)
+	assert.Equal(t, float32(20), mgr.getRoom("123").Time, "cached for the connection")
+}
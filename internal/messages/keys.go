@@ -0,0 +1,120 @@
+package messages
+
+// Key identifies a bot message, channels can override or silence each key
+type Key string
+
+const (
+	EmoteSevenTvAdded            Key = "emote.seventv.added"
+	EmoteSevenTvRemoved          Key = "emote.seventv.removed"
+	EmoteSevenTvRedeemed         Key = "emote.seventv.redeemed"
+	EmoteSevenTvRedeemedReplaced Key = "emote.seventv.redeemed.replaced"
+	EmoteSevenTvRedemptionFailed Key = "emote.seventv.redemption.failed"
+	EmoteSevenTvApproval         Key = "emote.seventv.approval"
+	EmoteSevenTvExpired          Key = "emote.seventv.expired"
+	EmoteBttvAdded               Key = "emote.bttv.added"
+	EmoteBttvRemoved             Key = "emote.bttv.removed"
+	EmoteBttvRedeemed            Key = "emote.bttv.redeemed"
+	EmoteBttvRedeemedReplaced    Key = "emote.bttv.redeemed.replaced"
+	EmoteBttvRedemptionFailed    Key = "emote.bttv.redemption.failed"
+	EmoteBttvApproval            Key = "emote.bttv.approval"
+	EmoteBttvExpired             Key = "emote.bttv.expired"
+	EmoteRedemptionRejected      Key = "emote.redemption.rejected"
+	EmoteBlocked                 Key = "emote.blocked"
+	EmoteCommandError            Key = "emote.command.error"
+	EmoteInfo                    Key = "emote.info"
+	EmoteInfoUnknown             Key = "emote.info.unknown"
+
+	PredictionBegin    Key = "prediction.begin"
+	PredictionLock     Key = "prediction.lock"
+	PredictionEnd      Key = "prediction.end"
+	PredictionCanceled Key = "prediction.canceled"
+
+	PollBegin      Key = "poll.begin"
+	PollEnd        Key = "poll.end"
+	PollEndWithout Key = "poll.end.novotes"
+
+	CommandError  Key = "command.error"
+	CommandUsage  Key = "command.usage"
+	CommandStatus Key = "command.status"
+	HelpCommands  Key = "help.commands"
+	HelpDescribe  Key = "help.describe"
+	HelpUnknown   Key = "help.unknown"
+
+	QuoteShow    Key = "quote.show"
+	QuoteAdded   Key = "quote.added"
+	QuoteDeleted Key = "quote.deleted"
+
+	ModerationPermit Key = "moderation.permit"
+	ShoutoutMessage  Key = "shoutout.announcement"
+	ShoutoutNotFound Key = "shoutout.notfound"
+	ShoutoutFailed   Key = "shoutout.failed"
+	MediaInvalidUrl  Key = "media.invalidurl"
+)
+
+type Definition struct {
+	Key          Key
+	Description  string
+	Default      string
+	Placeholders []string
+}
+
+var definitions = []Definition{
+	{EmoteSevenTvAdded, "A moderator added a 7TV emote", "✅ Added new 7TV emote {emote} by @{user}", []string{"emote", "user"}},
+	{EmoteSevenTvRemoved, "A moderator removed a 7TV emote", "✅ Removed 7TV emote {emote} by @{user}", []string{"emote", "user"}},
+	{EmoteSevenTvRedeemed, "A 7TV emote was added by a redemption", "✅ Added new 7TV emote {emote} redeemed by @{user}", []string{"emote", "user"}},
+	{EmoteSevenTvRedeemedReplaced, "A 7TV emote was added by a redemption and replaced another emote", "✅ Added new 7TV emote {emote} redeemed by @{user} removed {removed}", []string{"emote", "user", "removed"}},
+	{EmoteSevenTvRedemptionFailed, "A 7TV emote redemption failed", "⚠️ Failed to add 7TV emote from @{user} error: {error}", []string{"user", "error"}},
+	{EmoteSevenTvApproval, "A 7TV emote redemption is waiting for approval", "A new 7TV emote is waiting for approval, redeemed by @{user}", []string{"user"}},
+	{EmoteSevenTvExpired, "A redeemed 7TV emote expired and was removed", "⌛ 7TV emote {emote} expired and was removed", []string{"emote"}},
+	{EmoteBttvAdded, "A moderator added a bttv emote", "✅ Added new bttv emote {emote} by @{user}", []string{"emote", "user"}},
+	{EmoteBttvRemoved, "A moderator removed a bttv emote", "✅ Removed bttv emote {emote} by @{user}", []string{"emote", "user"}},
+	{EmoteBttvRedeemed, "A bttv emote was added by a redemption", "✅ Added new bttv emote {emote} redeemed by @{user}", []string{"emote", "user"}},
+	{EmoteBttvRedeemedReplaced, "A bttv emote was added by a redemption and replaced another emote", "✅ Added new bttv emote {emote} redeemed by @{user} removed: {removed}", []string{"emote", "user", "removed"}},
+	{EmoteBttvRedemptionFailed, "A bttv emote redemption failed", "⚠️ Failed to add bttv emote from @{user} error: {error}", []string{"user", "error"}},
+	{EmoteBttvApproval, "A bttv emote redemption is waiting for approval", "A new Bttv emote is waiting for approval, redeemed by @{user}", []string{"user"}},
+	{EmoteBttvExpired, "A redeemed bttv emote expired and was removed", "⌛ bttv emote {emote} expired and was removed", []string{"emote"}},
+	{EmoteRedemptionRejected, "An emote redemption was rejected", "⚠️ Emote redemption by @{user} was rejected", []string{"user"}},
+	{EmoteBlocked, "An emote was removed and blocked from the dashboard", "⚠️ Emote {emote} has been removed and blocked", []string{"emote"}},
+	{EmoteCommandError, "An emote command failed", "⚠️ @{user} {error}", []string{"user", "error"}},
+	{EmoteInfo, "Answer of !emoteinfo", "@{user} {info}", []string{"user", "emote", "info"}},
+	{EmoteInfoUnknown, "!emoteinfo for an emote that is not in the channel", "@{user} emote {emote} is not a 7TV or shared bttv emote of this channel", []string{"user", "emote"}},
+	{PredictionBegin, "A prediction started", "PogChamp prediction: {title} [ {outcomes} ] ending in {duration}", []string{"title", "outcomes", "duration"}},
+	{PredictionLock, "A prediction was locked", "FBtouchdown locked submissions for: {title}", []string{"title"}},
+	{PredictionEnd, "A prediction was resolved", "PogChamp ended prediction: {title} Winner: {color} {winner}", []string{"title", "color", "winner"}},
+	{PredictionCanceled, "A prediction was canceled", "NinjaGrumpy canceled prediction: {title}", []string{"title"}},
+	{PollBegin, "A poll started", "PogChamp poll: {title} [ {choices} ] ending in {duration}", []string{"title", "choices", "duration"}},
+	{PollEnd, "A poll ended", "PogChamp poll ended: {title} Winner: {winner} with {votes} of {total} votes", []string{"title", "winner", "votes", "total"}},
+	{PollEndWithout, "A poll ended without votes", "NinjaGrumpy poll ended without votes: {title}", []string{"title"}},
+	{CommandError, "A command failed", "@{user} {error}", []string{"user", "error"}},
+	{CommandUsage, "A command was used without arguments", "@{user}, usage: {usage}", []string{"user", "usage"}},
+	{CommandStatus, "Answer of !status", "@{user}, uptime: {uptime}, dropped on cooldown: {dropped}", []string{"user", "uptime", "dropped"}},
+	{HelpCommands, "Answer of !commands", "@{user} commands: {commands}", []string{"user", "commands"}},
+	{HelpDescribe, "Answer of !help <command>", "@{user} {description}", []string{"user", "command", "description"}},
+	{HelpUnknown, "!help for an unknown command", "@{user} unknown command {command}", []string{"user", "command"}},
+	{QuoteShow, "A quote is shown", "#{number}: {text} [{details}]", []string{"number", "text", "details", "category", "date", "author"}},
+	{QuoteAdded, "A quote was added", "@{user} added quote #{number}", []string{"user", "number"}},
+	{QuoteDeleted, "A quote was deleted", "@{user} deleted quote #{number}", []string{"user", "number"}},
+	{ModerationPermit, "A user was permitted to post a link", "@{user} may post a link in the next {seconds} seconds", []string{"user", "seconds"}},
+	{ShoutoutMessage, "Announcement sent with a shoutout", "Go check out {user} at twitch.tv/{login}, they were last playing {category}!", []string{"user", "login", "category", "title"}},
+	{ShoutoutNotFound, "!so for an unknown user", "@{user}, user {login} not found", []string{"user", "login"}},
+	{ShoutoutFailed, "A shoutout failed", "@{user}, {error}", []string{"user", "error"}},
+	{MediaInvalidUrl, "Reply to a song request without a youtube link", "invalid youtube url", []string{"user"}},
+}
+
+var definitionsByKey = map[Key]Definition{}
+
+func init() {
+	for _, definition := range definitions {
+		definitionsByKey[definition.Key] = definition
+	}
+}
+
+// Definitions lists every message key with its default text
+func Definitions() []Definition {
+	return definitions
+}
+
+func GetDefinition(key Key) (Definition, bool) {
+	definition, ok := definitionsByKey[key]
+	return definition, ok
+}
@@ -0,0 +1,93 @@
+package messages
+
+import (
+	"regexp"
+
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/puzpuzpuz/xsync"
+)
+
+var placeholderRegex = regexp.MustCompile(`\{(\w+)\}`)
+
+// Values fills the placeholders of a message, {user} is replaced by Values{"user": "gempir"}
+type Values map[string]string
+
+type storage interface {
+	GetMessageTemplates(channelTwitchID string) []store.MessageTemplate
+}
+
+// Messenger renders bot messages with the templates of a channel and sends them to chat
+type Messenger struct {
+	db       storage
+	say      func(channel, message string)
+	channels *xsync.MapOf[string, map[Key]store.MessageTemplate]
+}
+
+func NewMessenger(db storage, say func(channel, message string)) *Messenger {
+	return &Messenger{
+		db:       db,
+		say:      say,
+		channels: xsync.NewMapOf[map[Key]store.MessageTemplate](),
+	}
+}
+
+// LoadChannel (re)loads the templates of a channel, call after changing them via the api
+func (m *Messenger) LoadChannel(channelID string) map[Key]store.MessageTemplate {
+	templates := map[Key]store.MessageTemplate{}
+	for _, template := range m.db.GetMessageTemplates(channelID) {
+		templates[Key(template.Key)] = template
+	}
+	m.channels.Store(channelID, templates)
+
+	return templates
+}
+
+// Render returns the message of key in the channel, false when the channel silenced it
+func (m *Messenger) Render(channelID string, key Key, values Values) (string, bool) {
+	templates, ok := m.channels.Load(channelID)
+	if !ok {
+		templates = m.LoadChannel(channelID)
+	}
+
+	text := ""
+	if definition, ok := definitionsByKey[key]; ok {
+		text = definition.Default
+	} else {
+		log.Warnf("unknown message key %s", key)
+	}
+
+	if template, ok := templates[key]; ok {
+		if template.Disabled {
+			return "", false
+		}
+		if template.Text != "" {
+			text = template.Text
+		}
+	}
+
+	message := Format(text, values)
+
+	return message, message != ""
+}
+
+// Say renders the message and sends it to the channel unless it was silenced
+func (m *Messenger) Say(channelID string, channel string, key Key, values Values) {
+	message, ok := m.Render(channelID, key, values)
+	if !ok {
+		return
+	}
+
+	m.say(channel, message)
+}
+
+// Format replaces the placeholders in text, unknown placeholders are kept as they are
+func Format(text string, values Values) string {
+	return placeholderRegex.ReplaceAllStringFunc(text, func(placeholder string) string {
+		if value, ok := values[placeholder[1:len(placeholder)-1]]; ok {
+			return value
+		}
+
+		return placeholder
+	})
+}
@@ -0,0 +1,42 @@
+package messages
+
+import (
+	"testing"
+
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+type templateStore struct {
+	templates []store.MessageTemplate
+}
+
+func (s *templateStore) GetMessageTemplates(channelTwitchID string) []store.MessageTemplate {
+	return s.templates
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "✅ Added new 7TV emote Kappa by @gempir", Format("✅ Added new 7TV emote {emote} by @{user}", Values{"emote": "Kappa", "user": "gempir"}))
+	assert.Equal(t, "{unknown} gempir", Format("{unknown} {user}", Values{"user": "gempir"}))
+}
+
+func TestRender(t *testing.T) {
+	db := &templateStore{templates: []store.MessageTemplate{
+		{ChannelTwitchID: "1", Key: string(PredictionLock), Text: "Vorhersage gesperrt: {title}"},
+		{ChannelTwitchID: "1", Key: string(PollBegin), Disabled: true},
+	}}
+	sent := []string{}
+	messenger := NewMessenger(db, func(channel, message string) {
+		sent = append(sent, message)
+	})
+
+	messenger.Say("1", "gempir", PredictionLock, Values{"title": "win?"})
+	messenger.Say("1", "gempir", PollBegin, Values{"title": "win?"})
+	messenger.Say("1", "gempir", PredictionCanceled, Values{"title": "win?"})
+
+	assert.Equal(t, []string{"Vorhersage gesperrt: win?", "NinjaGrumpy canceled prediction: win?"}, sent)
+}
+
+func TestDefinitionsAreUnique(t *testing.T) {
+	assert.Len(t, definitionsByKey, len(Definitions()))
+}
@@ -0,0 +1,241 @@
+package moderation
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gempir/gempbot/internal/chat/tmi"
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/helixclient"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/messages"
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/gempir/go-twitch-irc/v4"
+	"github.com/puzpuzpuz/xsync"
+)
+
+const (
+	CmdNamePermit = "permit"
+
+	defaultTimeoutSeconds = 600
+	defaultPermitSeconds  = 60
+
+	punishmentBufferSize = 1000
+)
+
+var linkRegex = regexp.MustCompile(`(?i)(https?://\S+|\b[a-z0-9-]+(\.[a-z0-9-]+)*\.(com|net|org|tv|gg|io|me|co|de|uk|ru|xyz|ly|be|info|link|app|dev)\b)`)
+
+type storage interface {
+	GetModerationConfig(channelTwitchID string) (store.ModerationConfig, error)
+	GetBannedPhrases(channelTwitchID string) []store.BannedPhrase
+	GetChannelUserPermissions(userID string, channelID string) store.Permission
+}
+
+type moderationBot interface {
+	RegisterCommand(command dto.Command)
+}
+
+// Moderator deletes, times out and bans chatters posting banned phrases or links
+type Moderator struct {
+	db          storage
+	helixClient helixclient.Client
+	messenger   *messages.Messenger
+	channels    *xsync.MapOf[string, *channelRules]
+	offenses    *offenses
+	punishments chan punishment
+}
+
+// punishment is queued by the chat reader, the helix calls run in Start
+type punishment struct {
+	cfg    store.ModerationConfig
+	msg    twitch.PrivateMessage
+	reason string
+	strike int
+}
+
+type channelRules struct {
+	cfg     store.ModerationConfig
+	phrases []string
+	regexes []*regexp.Regexp
+}
+
+func NewModerator(db storage, helixClient helixclient.Client, bot moderationBot, messenger *messages.Messenger) *Moderator {
+	m := &Moderator{
+		db:          db,
+		helixClient: helixClient,
+		messenger:   messenger,
+		channels:    xsync.NewMapOf[*channelRules](),
+		offenses:    newOffenses(),
+		punishments: make(chan punishment, punishmentBufferSize),
+	}
+
+	bot.RegisterCommand(dto.Command{
+		Name:        CmdNamePermit,
+		Description: "Allows a user to post a link",
+		Usage:       CmdNamePermit + " <user>",
+		Capability:  dto.CapabilityModeration,
+		Handler:     m.handlePermit,
+	})
+
+	return m
+}
+
+// LoadChannel (re)loads the moderation config and banned phrases of a channel, call after changing them via the api
+func (m *Moderator) LoadChannel(channelID string) {
+	cfg, _ := m.db.GetModerationConfig(channelID)
+	rules := &channelRules{cfg: cfg}
+
+	for _, phrase := range m.db.GetBannedPhrases(channelID) {
+		if !phrase.Regex {
+			rules.phrases = append(rules.phrases, strings.ToLower(phrase.Phrase))
+			continue
+		}
+
+		re, err := regexp.Compile(phrase.Phrase)
+		if err != nil {
+			log.Warnf("invalid banned phrase regex in %s: %s", channelID, err)
+			continue
+		}
+		rules.regexes = append(rules.regexes, re)
+	}
+
+	m.channels.Store(channelID, rules)
+}
+
+func (m *Moderator) getChannelRules(channelID string) *channelRules {
+	rules, ok := m.channels.Load(channelID)
+	if !ok {
+		m.LoadChannel(channelID)
+		rules, _ = m.channels.Load(channelID)
+	}
+
+	return rules
+}
+
+func (m *Moderator) HandlePrivateMessage(msg twitch.PrivateMessage) {
+	rules := m.getChannelRules(msg.RoomID)
+	if !rules.cfg.Enabled {
+		return
+	}
+
+	reason := rules.violation(msg.Message)
+	if reason == "" {
+		return
+	}
+	if reason == reasonLink && m.offenses.hasPermit(msg.RoomID, msg.User.Name) {
+		return
+	}
+	if m.isExempt(msg) {
+		return
+	}
+
+	select {
+	case m.punishments <- punishment{cfg: rules.cfg, msg: msg, reason: reason, strike: m.offenses.strike(msg.RoomID, msg.User.ID)}:
+	default:
+		log.Warnf("[%s] moderation queue full, not punishing %s", msg.Channel, msg.User.Name)
+	}
+}
+
+// Start punishes the queued offenses, the helix calls would block the chat reader otherwise
+func (m *Moderator) Start() {
+	for p := range m.punishments {
+		m.punish(p)
+	}
+}
+
+func (m *Moderator) isExempt(msg twitch.PrivateMessage) bool {
+	return tmi.IsModerator(msg.User) || tmi.IsBroadcaster(msg.User) || m.db.GetChannelUserPermissions(msg.User.ID, msg.RoomID).HasAny()
+}
+
+func (m *Moderator) punish(p punishment) {
+	cfg, msg, reason, strike := p.cfg, p.msg, p.reason, p.strike
+
+	var err error
+	switch action(cfg, strike) {
+	case actionBan:
+		log.Infof("[%s] banning %s, strike %d: %s", msg.Channel, msg.User.Name, strike, reason)
+		err = m.helixClient.BanUser(msg.RoomID, msg.User.ID, 0, reason)
+	case actionTimeout:
+		log.Infof("[%s] timing out %s, strike %d: %s", msg.Channel, msg.User.Name, strike, reason)
+		err = m.helixClient.BanUser(msg.RoomID, msg.User.ID, timeoutSeconds(cfg), reason)
+	default:
+		log.Infof("[%s] deleting message of %s, strike %d: %s", msg.Channel, msg.User.Name, strike, reason)
+		err = m.helixClient.DeleteChatMessage(msg.RoomID, msg.ID)
+	}
+	if err != nil {
+		log.Errorf("[%s] failed to moderate %s: %s", msg.Channel, msg.User.Name, err)
+	}
+}
+
+// handlePermit handles "!permit <user>"
+func (m *Moderator) handlePermit(payload dto.CommandPayload) {
+	user := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(payload.Query), "@"))
+	if user == "" {
+		m.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.CommandUsage, messages.Values{"user": payload.Msg.User.DisplayName, "usage": payload.Prefix + CmdNamePermit + " <user>"})
+		return
+	}
+
+	cfg := m.getChannelRules(payload.Msg.RoomID).cfg
+	seconds := cfg.PermitSeconds
+	if seconds <= 0 {
+		seconds = defaultPermitSeconds
+	}
+
+	m.offenses.permit(payload.Msg.RoomID, user, time.Duration(seconds)*time.Second)
+	m.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.ModerationPermit, messages.Values{"user": user, "seconds": strconv.Itoa(seconds)})
+}
+
+const (
+	reasonBannedPhrase = "banned phrase"
+	reasonLink         = "link"
+)
+
+// violation returns why the message is not allowed, empty when it is fine
+func (r *channelRules) violation(message string) string {
+	lower := strings.ToLower(message)
+	for _, phrase := range r.phrases {
+		if strings.Contains(lower, phrase) {
+			return reasonBannedPhrase
+		}
+	}
+	for _, re := range r.regexes {
+		if re.MatchString(message) {
+			return reasonBannedPhrase
+		}
+	}
+	if r.cfg.FilterLinks && linkRegex.MatchString(message) {
+		return reasonLink
+	}
+
+	return ""
+}
+
+type moderationAction int
+
+const (
+	actionDelete moderationAction = iota
+	actionTimeout
+	actionBan
+)
+
+// action escalates from deleting the first offense to timeouts and finally a ban
+func action(cfg store.ModerationConfig, strike int) moderationAction {
+	if cfg.BanAfterStrikes > 0 && strike >= cfg.BanAfterStrikes {
+		return actionBan
+	}
+	if strike <= 1 {
+		return actionDelete
+	}
+
+	return actionTimeout
+}
+
+func timeoutSeconds(cfg store.ModerationConfig) int {
+	if cfg.TimeoutSeconds <= 0 {
+		return defaultTimeoutSeconds
+	}
+
+	return cfg.TimeoutSeconds
+}
@@ -0,0 +1,92 @@
+package moderation
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/gempir/go-twitch-irc/v4"
+	"github.com/puzpuzpuz/xsync"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViolation(t *testing.T) {
+	rules := &channelRules{
+		cfg:     store.ModerationConfig{FilterLinks: true},
+		phrases: []string{"buy followers"},
+		regexes: []*regexp.Regexp{regexp.MustCompile(`(?i)f+r+e+\s*v+b+u+c+k+s`)},
+	}
+
+	assert.Equal(t, "", rules.violation("hello chat"))
+	assert.Equal(t, reasonBannedPhrase, rules.violation("BUY FOLLOWERS cheap"))
+	assert.Equal(t, reasonBannedPhrase, rules.violation("freee vbucks"))
+	assert.Equal(t, reasonLink, rules.violation("check out example.com"))
+	assert.Equal(t, reasonLink, rules.violation("https://twitch.tv/gempir"))
+	assert.Equal(t, "", rules.violation("e.g. this is fine"))
+
+	rules.cfg.FilterLinks = false
+	assert.Equal(t, "", rules.violation("check out example.com"))
+}
+
+func TestActionEscalates(t *testing.T) {
+	cfg := store.ModerationConfig{BanAfterStrikes: 3}
+
+	assert.Equal(t, actionDelete, action(cfg, 1))
+	assert.Equal(t, actionTimeout, action(cfg, 2))
+	assert.Equal(t, actionBan, action(cfg, 3))
+
+	assert.Equal(t, actionTimeout, action(store.ModerationConfig{}, 10), "0 never bans")
+}
+
+func TestStrikesExpire(t *testing.T) {
+	now := time.Now()
+	o := newOffenses()
+	o.now = func() time.Time { return now }
+
+	assert.Equal(t, 1, o.strike("channel", "user"))
+	assert.Equal(t, 2, o.strike("channel", "user"))
+	assert.Equal(t, 1, o.strike("otherchannel", "user"))
+
+	now = now.Add(strikeExpiry + time.Second)
+	assert.Equal(t, 1, o.strike("channel", "user"))
+}
+
+func TestPermitExpires(t *testing.T) {
+	now := time.Now()
+	o := newOffenses()
+	o.now = func() time.Time { return now }
+
+	assert.False(t, o.hasPermit("channel", "gempir"))
+	o.permit("channel", "gempir", time.Minute)
+	assert.True(t, o.hasPermit("channel", "gempir"))
+	assert.False(t, o.hasPermit("otherchannel", "gempir"))
+
+	now = now.Add(time.Minute)
+	assert.False(t, o.hasPermit("channel", "gempir"))
+}
+
+// noPermissions only answers the exempt check, the channel rules are preloaded
+type noPermissions struct {
+	storage
+}
+
+func (noPermissions) GetChannelUserPermissions(userID string, channelID string) store.Permission {
+	return store.Permission{}
+}
+
+func TestHandlePrivateMessageQueuesPunishment(t *testing.T) {
+	m := &Moderator{
+		db:          noPermissions{},
+		channels:    xsync.NewMapOf[*channelRules](),
+		offenses:    newOffenses(),
+		punishments: make(chan punishment, 1),
+	}
+	m.channels.Store("channel", &channelRules{cfg: store.ModerationConfig{Enabled: true}, phrases: []string{"buy followers"}})
+
+	m.HandlePrivateMessage(twitch.PrivateMessage{RoomID: "channel", User: twitch.User{ID: "user"}, Message: "buy followers"})
+
+	queued := <-m.punishments
+	assert.Equal(t, reasonBannedPhrase, queued.reason)
+	assert.Equal(t, 1, queued.strike)
+}
@@ -0,0 +1,80 @@
+package moderation
+
+import (
+	"sync"
+	"time"
+)
+
+// strikes are forgotten after a while of good behaviour
+const strikeExpiry = 6 * time.Hour
+
+type offense struct {
+	count int
+	last  time.Time
+}
+
+type offenses struct {
+	mu      sync.Mutex
+	strikes map[string]offense
+	permits map[string]time.Time
+	now     func() time.Time
+}
+
+func newOffenses() *offenses {
+	return &offenses{
+		strikes: map[string]offense{},
+		permits: map[string]time.Time{},
+		now:     time.Now,
+	}
+}
+
+// strike records an offense and returns how many the user has collected
+func (o *offenses) strike(channelID, userID string) int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := o.now()
+	key := channelID + ":" + userID
+
+	current := o.strikes[key]
+	if now.Sub(current.last) > strikeExpiry {
+		current.count = 0
+	}
+	current.count++
+	current.last = now
+	o.strikes[key] = current
+	o.prune(now)
+
+	return current.count
+}
+
+func (o *offenses) permit(channelID, login string, duration time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.permits[channelID+":"+login] = o.now().Add(duration)
+}
+
+func (o *offenses) hasPermit(channelID, login string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.now().Before(o.permits[channelID+":"+login])
+}
+
+func (o *offenses) prune(now time.Time) {
+	if len(o.strikes)+len(o.permits) < 1000 {
+		return
+	}
+
+	for key, strike := range o.strikes {
+		if now.Sub(strike.last) > strikeExpiry {
+			delete(o.strikes, key)
+		}
+	}
+	for key, until := range o.permits {
+		if !now.Before(until) {
+			delete(o.permits, key)
+		}
+	}
+}
@@ -4,12 +4,16 @@ import (
 	"github.com/gempir/gempbot/internal/auth"
 	"github.com/gempir/gempbot/internal/bot"
 	"github.com/gempir/gempbot/internal/channelpoint"
+	"github.com/gempir/gempbot/internal/chatlog"
 	"github.com/gempir/gempbot/internal/config"
+	"github.com/gempir/gempbot/internal/dryrun"
 	"github.com/gempir/gempbot/internal/emotechief"
 	"github.com/gempir/gempbot/internal/emoteservice"
 	"github.com/gempir/gempbot/internal/eventsubmanager"
 	"github.com/gempir/gempbot/internal/helixclient"
+	"github.com/gempir/gempbot/internal/moderation"
 	"github.com/gempir/gempbot/internal/store"
+	"github.com/gempir/gempbot/internal/timer"
 	"github.com/gempir/gempbot/internal/user"
 	"github.com/gempir/gempbot/internal/ws"
 )
@@ -26,9 +30,13 @@ type Api struct {
 	channelPointManager *channelpoint.ChannelPointManager
 	sevenTvClient       emoteservice.ApiClient
 	wsHandler           *ws.WsHandler
+	timerScheduler      *timer.Scheduler
+	moderator           *moderation.Moderator
+	dryRun              *dryrun.Recorder
+	chatLogger          *chatlog.Logger
 }
 
-func NewApi(cfg *config.Config, db *store.Database, helixClient helixclient.Client, userAdmin *user.UserAdmin, authClient *auth.Auth, bot *bot.Bot, emoteChief *emotechief.EmoteChief, eventsubManager *eventsubmanager.EventsubManager, channelPointManager *channelpoint.ChannelPointManager, sevenTvClient emoteservice.ApiClient, wsHandler *ws.WsHandler) *Api {
+func NewApi(cfg *config.Config, db *store.Database, helixClient helixclient.Client, userAdmin *user.UserAdmin, authClient *auth.Auth, bot *bot.Bot, emoteChief *emotechief.EmoteChief, eventsubManager *eventsubmanager.EventsubManager, channelPointManager *channelpoint.ChannelPointManager, sevenTvClient emoteservice.ApiClient, wsHandler *ws.WsHandler, timerScheduler *timer.Scheduler, moderator *moderation.Moderator, dryRun *dryrun.Recorder, chatLogger *chatlog.Logger) *Api {
 	return &Api{
 		db:                  db,
 		cfg:                 cfg,
@@ -41,5 +49,9 @@ func NewApi(cfg *config.Config, db *store.Database, helixClient helixclient.Clie
 		channelPointManager: channelPointManager,
 		sevenTvClient:       sevenTvClient,
 		wsHandler:           wsHandler,
+		timerScheduler:      timerScheduler,
+		moderator:           moderator,
+		dryRun:              dryRun,
+		chatLogger:          chatLogger,
 	}
 }
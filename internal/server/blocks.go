@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/gempir/gempbot/internal/api"
+	"github.com/gempir/gempbot/internal/dto"
 	"github.com/gempir/gempbot/internal/store"
 )
 
@@ -18,7 +19,7 @@ func (a *Api) BlocksHandler(w http.ResponseWriter, r *http.Request) {
 	userID := authResp.Data.UserID
 
 	if r.URL.Query().Get("managing") != "" {
-		userID, apiErr = a.userAdmin.CheckEditor(r, a.userAdmin.GetUserConfig(userID))
+		userID, apiErr = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityBlocks)
 		if apiErr != nil {
 			http.Error(w, apiErr.Error(), apiErr.Status())
 			return
@@ -6,12 +6,21 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/gempir/gempbot/internal/api"
+	"github.com/gempir/gempbot/internal/chat"
+	"github.com/gempir/gempbot/internal/dto"
 	"github.com/gempir/gempbot/internal/log"
 	"github.com/gempir/gempbot/internal/store"
 )
 
+type botConfigResponse struct {
+	store.BotConfig
+	JoinState chat.JoinState
+}
+
 func (a *Api) BotConfigHandler(w http.ResponseWriter, r *http.Request) {
 	authResp, _, apiErr := a.authClient.AttemptAuth(r, w)
 	if apiErr != nil {
@@ -21,7 +30,7 @@ func (a *Api) BotConfigHandler(w http.ResponseWriter, r *http.Request) {
 	ownerLogin := authResp.Data.Login
 
 	if r.URL.Query().Get("managing") != "" {
-		userID, apiErr = a.userAdmin.CheckEditor(r, a.userAdmin.GetUserConfig(userID))
+		userID, apiErr = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityBotConfig)
 		if apiErr != nil {
 			http.Error(w, apiErr.Error(), apiErr.Status())
 			return
@@ -41,7 +50,7 @@ func (a *Api) BotConfigHandler(w http.ResponseWriter, r *http.Request) {
 			log.Error(err)
 		}
 
-		api.WriteJson(w, cfg, http.StatusOK)
+		api.WriteJson(w, botConfigResponse{BotConfig: cfg, JoinState: a.bot.JoinState(ownerLogin)}, http.StatusOK)
 		return
 	} else if r.Method == http.MethodPost {
 		body, err := io.ReadAll(r.Body)
@@ -58,6 +67,13 @@ func (a *Api) BotConfigHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		botCfg.OwnerTwitchID = userID
+		// saving tries to join again
+		botCfg.JoinFailure = ""
+		botCfg.CommandPrefix = strings.TrimSpace(botCfg.CommandPrefix)
+		if !isValidCommandPrefix(botCfg.CommandPrefix) {
+			http.Error(w, "command prefix must be 1-3 characters, without spaces and not start with / or .", http.StatusBadRequest)
+			return
+		}
 
 		dbErr := a.db.SaveBotConfig(context.Background(), botCfg)
 		if dbErr != nil {
@@ -65,14 +81,32 @@ func (a *Api) BotConfigHandler(w http.ResponseWriter, r *http.Request) {
 			api.WriteJson(w, fmt.Errorf("failed to save bot config"), http.StatusInternalServerError)
 			return
 		}
+		a.bot.ReloadChannelConfig(userID)
+		a.dryRun.SetChannel(userID, botCfg.DryRun)
+		a.chatLogger.SetChannel(userID, botCfg.ChatLogs)
 		if botCfg.JoinBot {
-			a.bot.Join(ownerLogin)
+			a.bot.Join(userID, ownerLogin)
+			a.eventsubManager.SubscribeStreamStatus(userID)
 		} else {
 			a.bot.Part(ownerLogin)
 		}
+		if botCfg.AutoShoutoutRaids {
+			a.eventsubManager.SubscribeRaids(userID)
+		} else {
+			a.eventsubManager.UnsubscribeRaids(userID)
+		}
 
 		return
 	}
 
 	http.Error(w, "unknown method", http.StatusMethodNotAllowed)
 }
+
+// isValidCommandPrefix allows an empty prefix which falls back to the default "!"
+func isValidCommandPrefix(prefix string) bool {
+	if prefix == "" {
+		return true
+	}
+
+	return utf8.RuneCountInString(prefix) <= 3 && !strings.ContainsAny(prefix, " \t") && !strings.HasPrefix(prefix, "/") && !strings.HasPrefix(prefix, ".")
+}
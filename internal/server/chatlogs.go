@@ -0,0 +1,69 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gempir/gempbot/internal/api"
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/store"
+)
+
+const (
+	defaultChatLogsLimit = 100
+	maxChatLogsLimit     = 1000
+)
+
+func (a *Api) ChatLogsHandler(w http.ResponseWriter, r *http.Request) {
+	authResp, _, apiErr := a.authClient.AttemptAuth(r, w)
+	if apiErr != nil {
+		return
+	}
+	userID := authResp.Data.UserID
+
+	if r.URL.Query().Get("managing") != "" {
+		userID, apiErr = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityModeration)
+		if apiErr != nil {
+			http.Error(w, apiErr.Error(), apiErr.Status())
+			return
+		}
+	}
+
+	if r.Method == http.MethodGet {
+		query := store.ChatMessageQuery{
+			ChannelTwitchID: userID,
+			UserLogin:       r.URL.Query().Get("user"),
+			Text:            r.URL.Query().Get("q"),
+			Limit:           defaultChatLogsLimit,
+		}
+
+		var err error
+		if from := r.URL.Query().Get("from"); from != "" {
+			query.From, err = time.Parse(time.RFC3339, from)
+			if err != nil {
+				http.Error(w, "from must be a RFC3339 timestamp", http.StatusBadRequest)
+				return
+			}
+		}
+		if to := r.URL.Query().Get("to"); to != "" {
+			query.To, err = time.Parse(time.RFC3339, to)
+			if err != nil {
+				http.Error(w, "to must be a RFC3339 timestamp", http.StatusBadRequest)
+				return
+			}
+		}
+		if limit := r.URL.Query().Get("limit"); limit != "" {
+			query.Limit, err = strconv.Atoi(limit)
+			if err != nil || query.Limit < 1 || query.Limit > maxChatLogsLimit {
+				http.Error(w, "limit must be between 1 and 1000", http.StatusBadRequest)
+				return
+			}
+		}
+
+		api.WriteJson(w, a.db.SearchChatMessages(r.Context(), query), http.StatusOK)
+		return
+	}
+
+	http.Error(w, "unknown method", http.StatusMethodNotAllowed)
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gempir/gempbot/internal/api"
+	"github.com/gempir/gempbot/internal/dto"
+)
+
+func (a *Api) CommandRegistryHandler(w http.ResponseWriter, r *http.Request) {
+	authResp, _, apiErr := a.authClient.AttemptAuth(r, w)
+	if apiErr != nil {
+		return
+	}
+	userID := authResp.Data.UserID
+
+	if r.URL.Query().Get("managing") != "" {
+		userID, apiErr = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityCommands)
+		if apiErr != nil {
+			http.Error(w, apiErr.Error(), apiErr.Status())
+			return
+		}
+	}
+
+	if r.Method == http.MethodGet {
+		api.WriteJson(w, a.bot.Commands(userID), http.StatusOK)
+		return
+	}
+
+	http.Error(w, "unknown method", http.StatusMethodNotAllowed)
+}
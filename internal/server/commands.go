@@ -0,0 +1,82 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gempir/gempbot/internal/api"
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/store"
+)
+
+var commandNameRegex = regexp.MustCompile(`^\w+$`)
+
+func (a *Api) CommandsHandler(w http.ResponseWriter, r *http.Request) {
+	authResp, _, apiErr := a.authClient.AttemptAuth(r, w)
+	if apiErr != nil {
+		return
+	}
+	userID := authResp.Data.UserID
+
+	if r.URL.Query().Get("managing") != "" {
+		userID, apiErr = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityCommands)
+		if apiErr != nil {
+			http.Error(w, apiErr.Error(), apiErr.Status())
+			return
+		}
+	}
+
+	if r.Method == http.MethodGet {
+		api.WriteJson(w, a.db.GetCommands(userID), http.StatusOK)
+		return
+	} else if r.Method == http.MethodPost {
+		var command store.Command
+		if err := json.NewDecoder(r.Body).Decode(&command); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		command.Name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(command.Name), "!"))
+		command.Response = strings.TrimSpace(command.Response)
+		if !commandNameRegex.MatchString(command.Name) {
+			http.Error(w, "invalid command name", http.StatusBadRequest)
+			return
+		}
+		if command.Response == "" || len(command.Response) > 500 {
+			http.Error(w, "response must be between 1 and 500 characters", http.StatusBadRequest)
+			return
+		}
+		if a.bot.HasCommand(command.Name) {
+			http.Error(w, fmt.Sprintf("command name \"%s\" is reserved", command.Name), http.StatusBadRequest)
+			return
+		}
+		command.ChannelTwitchID = userID
+
+		err := a.db.SaveCommand(r.Context(), command)
+		if err != nil {
+			log.Error(err)
+			http.Error(w, "failed to save command", http.StatusInternalServerError)
+			return
+		}
+		a.bot.ReloadChannelConfig(userID)
+
+		api.WriteJson(w, "ok", http.StatusOK)
+		return
+	} else if r.Method == http.MethodDelete {
+		err := a.db.DeleteCommand(r.Context(), userID, strings.ToLower(r.URL.Query().Get("name")))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		a.bot.ReloadChannelConfig(userID)
+
+		api.WriteJson(w, "ok", http.StatusOK)
+		return
+	}
+
+	http.Error(w, "unknown method", http.StatusMethodNotAllowed)
+}
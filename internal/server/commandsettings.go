@@ -0,0 +1,113 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gempir/gempbot/internal/api"
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/store"
+)
+
+const maxCommandCooldown = 3600
+
+func (a *Api) CommandSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	authResp, _, apiErr := a.authClient.AttemptAuth(r, w)
+	if apiErr != nil {
+		return
+	}
+	userID := authResp.Data.UserID
+
+	if r.URL.Query().Get("managing") != "" {
+		userID, apiErr = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityCommands)
+		if apiErr != nil {
+			http.Error(w, apiErr.Error(), apiErr.Status())
+			return
+		}
+	}
+
+	if r.Method == http.MethodGet {
+		api.WriteJson(w, a.db.GetCommandSettings(userID), http.StatusOK)
+		return
+	} else if r.Method == http.MethodPost {
+		var setting store.CommandSetting
+		if err := json.NewDecoder(r.Body).Decode(&setting); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		setting.Command = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(setting.Command), "!"))
+		if !commandNameRegex.MatchString(setting.Command) {
+			http.Error(w, "invalid command name", http.StatusBadRequest)
+			return
+		}
+		if !validCooldown(setting.GlobalCooldown) || !validCooldown(setting.UserCooldown) {
+			http.Error(w, "cooldowns must be between 0 and 3600 seconds", http.StatusBadRequest)
+			return
+		}
+		usedAliases := map[string]string{}
+		for _, other := range a.db.GetCommandSettings(userID) {
+			if other.Command == setting.Command {
+				continue
+			}
+			for _, alias := range strings.Split(other.Aliases, ",") {
+				usedAliases[alias] = other.Command
+			}
+		}
+
+		aliases := []string{}
+		for _, alias := range strings.Split(setting.Aliases, ",") {
+			alias = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(alias), "!"))
+			if alias == "" {
+				continue
+			}
+			if !commandNameRegex.MatchString(alias) || alias == setting.Command {
+				http.Error(w, fmt.Sprintf("invalid alias \"%s\"", alias), http.StatusBadRequest)
+				return
+			}
+			if a.bot.HasCommand(alias) {
+				http.Error(w, fmt.Sprintf("alias \"%s\" is a reserved command name", alias), http.StatusBadRequest)
+				return
+			}
+			if command, ok := usedAliases[alias]; ok {
+				http.Error(w, fmt.Sprintf("alias \"%s\" is already used by %s", alias, command), http.StatusBadRequest)
+				return
+			}
+			aliases = append(aliases, alias)
+		}
+		setting.Aliases = strings.Join(aliases, ",")
+		setting.ChannelTwitchID = userID
+
+		err := a.db.SaveCommandSetting(r.Context(), setting)
+		if err != nil {
+			log.Error(err)
+			http.Error(w, "failed to save command setting", http.StatusInternalServerError)
+			return
+		}
+		a.bot.ReloadChannelConfig(userID)
+
+		api.WriteJson(w, "ok", http.StatusOK)
+		return
+	} else if r.Method == http.MethodDelete {
+		err := a.db.DeleteCommandSetting(r.Context(), userID, strings.ToLower(r.URL.Query().Get("command")))
+		if err != nil {
+			log.Error(err)
+			http.Error(w, "failed to delete command setting", http.StatusInternalServerError)
+			return
+		}
+		a.bot.ReloadChannelConfig(userID)
+
+		api.WriteJson(w, "ok", http.StatusOK)
+		return
+	}
+
+	http.Error(w, "unknown method", http.StatusMethodNotAllowed)
+}
+
+// validCooldown accepts an unset cooldown, which keeps the default
+func validCooldown(cooldown *int) bool {
+	return cooldown == nil || (*cooldown >= 0 && *cooldown <= maxCommandCooldown)
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gempir/gempbot/internal/api"
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/store"
+)
+
+const dryRunActionsLimit = 500
+
+type dryRunResponse struct {
+	Enabled bool
+	Global  bool
+	Actions []store.DryRunAction
+}
+
+func (a *Api) DryRunHandler(w http.ResponseWriter, r *http.Request) {
+	authResp, _, apiErr := a.authClient.AttemptAuth(r, w)
+	if apiErr != nil {
+		return
+	}
+	userID := authResp.Data.UserID
+
+	if r.URL.Query().Get("managing") != "" {
+		userID, apiErr = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityBotConfig)
+		if apiErr != nil {
+			http.Error(w, apiErr.Error(), apiErr.Status())
+			return
+		}
+	}
+
+	if r.Method == http.MethodGet {
+		api.WriteJson(w, dryRunResponse{
+			Enabled: a.dryRun.Enabled(userID),
+			Global:  a.dryRun.Global(),
+			Actions: a.db.GetDryRunActions(userID, dryRunActionsLimit),
+		}, http.StatusOK)
+		return
+	} else if r.Method == http.MethodDelete {
+		err := a.db.DeleteDryRunActions(r.Context(), userID)
+		if err != nil {
+			log.Error(err)
+			http.Error(w, "failed to delete dry-run actions", http.StatusInternalServerError)
+			return
+		}
+
+		api.WriteJson(w, "ok", http.StatusOK)
+		return
+	}
+
+	http.Error(w, "unknown method", http.StatusMethodNotAllowed)
+}
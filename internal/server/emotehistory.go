@@ -8,6 +8,7 @@ import (
 	"github.com/gempir/gempbot/internal/api"
 	"github.com/gempir/gempbot/internal/dto"
 	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/messages"
 )
 
 func (a *Api) EmoteHistoryHandler(w http.ResponseWriter, r *http.Request) {
@@ -24,7 +25,7 @@ func (a *Api) EmoteHistoryHandler(w http.ResponseWriter, r *http.Request) {
 		login = authResult.Data.Login
 
 		if r.URL.Query().Get("managing") != "" {
-			userID, err = a.userAdmin.CheckEditor(r, a.userAdmin.GetUserConfig(userID))
+			userID, err = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityEmotes)
 			if err != nil {
 				http.Error(w, err.Error(), err.Status())
 				return
@@ -66,7 +67,9 @@ func (a *Api) EmoteHistoryHandler(w http.ResponseWriter, r *http.Request) {
 			}
 
 			err = a.sevenTvClient.RemoveEmote(userID, emoteID)
-			a.db.CreateEmoteAdd(userID, dto.REWARD_SEVENTV, emoteID, dto.EMOTE_ADD_REMOVED_BLOCKED)
+			if !a.dryRun.Enabled(userID) {
+				a.db.CreateEmoteAdd(userID, dto.REWARD_SEVENTV, emoteID, dto.EMOTE_ADD_REMOVED_BLOCKED)
+			}
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
 				return
@@ -78,20 +81,20 @@ func (a *Api) EmoteHistoryHandler(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 
-			a.bot.ChatClient.Say(login, fmt.Sprintf("⚠️ Emote %s has been removed and blocked", emote.Code))
+			a.bot.Messenger.Say(userID, login, messages.EmoteBlocked, messages.Values{"emote": emote.Code})
 		} else if emoteAdd.Type == dto.REWARD_BTTV {
 			err := a.db.BlockEmotes(userID, []string{emoteID}, string(dto.REWARD_BTTV))
 			if err != nil {
 				log.Error(err)
 			}
 
-			emote, err := a.emoteChief.RemoveBttvEmote(userID, emoteID)
+			emote, err := a.emoteChief.RemoveBttvEmote(userID, emoteID, dto.EMOTE_ADD_REMOVED_BLOCKED)
 			if err != nil || emote == nil {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
 				return
 			}
 
-			a.bot.ChatClient.Say(login, fmt.Sprintf("⚠️ Emote %s has been removed and blocked", emote.Code))
+			a.bot.Messenger.Say(userID, login, messages.EmoteBlocked, messages.Values{"emote": emote.Code})
 		}
 
 		api.WriteJson(w, "ok", http.StatusOK)
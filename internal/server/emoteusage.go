@@ -0,0 +1,111 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gempir/gempbot/internal/api"
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/store"
+)
+
+const (
+	defaultEmoteUsageDays = 30
+	maxEmoteUsageDays     = 365
+)
+
+type emoteUsageResponse struct {
+	EmoteID string
+	Type    dto.RewardType
+	Code    string
+	Total   int
+	Days    []emoteUsageDay
+	// History are the changes of the emote made by gempbot, empty for emotes added elsewhere
+	History []store.EmoteAdd
+}
+
+type emoteUsageDay struct {
+	Day   time.Time
+	Count int
+}
+
+type emoteUsageKey struct {
+	rewardType dto.RewardType
+	emoteID    string
+}
+
+func (a *Api) EmoteUsageHandler(w http.ResponseWriter, r *http.Request) {
+	authResp, _, apiErr := a.authClient.AttemptAuth(r, w)
+	if apiErr != nil {
+		return
+	}
+	userID := authResp.Data.UserID
+
+	if r.URL.Query().Get("managing") != "" {
+		userID, apiErr = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityEmotes)
+		if apiErr != nil {
+			http.Error(w, apiErr.Error(), apiErr.Status())
+			return
+		}
+	}
+
+	if r.Method == http.MethodGet {
+		days := defaultEmoteUsageDays
+		if param := r.URL.Query().Get("days"); param != "" {
+			var err error
+			days, err = strconv.Atoi(param)
+			if err != nil || days < 1 || days > maxEmoteUsageDays {
+				http.Error(w, "days must be between 1 and 365", http.StatusBadRequest)
+				return
+			}
+		}
+		from := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -days+1)
+
+		api.WriteJson(w, joinEmoteUsage(a.db.GetEmoteUsages(r.Context(), userID, from), a.db.GetEmoteAddsSince(r.Context(), userID, from)), http.StatusOK)
+		return
+	}
+
+	http.Error(w, "unknown method", http.StatusMethodNotAllowed)
+}
+
+// joinEmoteUsage groups the daily usage and the history by emote, most used first
+func joinEmoteUsage(usages []store.EmoteUsage, history []store.EmoteAdd) []emoteUsageResponse {
+	emotes := map[emoteUsageKey]*emoteUsageResponse{}
+	get := func(rewardType dto.RewardType, emoteID string) *emoteUsageResponse {
+		key := emoteUsageKey{rewardType: rewardType, emoteID: emoteID}
+		emote, ok := emotes[key]
+		if !ok {
+			emote = &emoteUsageResponse{EmoteID: emoteID, Type: rewardType, Days: []emoteUsageDay{}, History: []store.EmoteAdd{}}
+			emotes[key] = emote
+		}
+
+		return emote
+	}
+
+	for _, usage := range usages {
+		emote := get(usage.Type, usage.EmoteID)
+		emote.Code = usage.Code
+		emote.Total += usage.Count
+		emote.Days = append(emote.Days, emoteUsageDay{Day: usage.Day, Count: usage.Count})
+	}
+	for _, emoteAdd := range history {
+		emote := get(emoteAdd.Type, emoteAdd.EmoteID)
+		emote.History = append(emote.History, emoteAdd)
+	}
+
+	resp := []emoteUsageResponse{}
+	for _, emote := range emotes {
+		resp = append(resp, *emote)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		if resp[i].Total != resp[j].Total {
+			return resp[i].Total > resp[j].Total
+		}
+
+		return resp[i].EmoteID < resp[j].EmoteID
+	})
+
+	return resp
+}
@@ -30,6 +30,10 @@ func (a *Api) EventSubHandler(w http.ResponseWriter, r *http.Request) {
 		a.eventsubManager.HandlePredictionBegin(event)
 		return
 	}
+	if r.URL.Query().Get("type") == helix.EventSubTypeChannelPredictionProgress {
+		a.eventsubManager.HandlePredictionProgress(event)
+		return
+	}
 	if r.URL.Query().Get("type") == helix.EventSubTypeChannelPredictionLock {
 		a.eventsubManager.HandlePredictionLock(event)
 		return
@@ -38,6 +42,30 @@ func (a *Api) EventSubHandler(w http.ResponseWriter, r *http.Request) {
 		a.eventsubManager.HandlePredictionEnd(event)
 		return
 	}
+	if r.URL.Query().Get("type") == helix.EventSubTypeChannelPollBegin {
+		a.eventsubManager.HandlePollBegin(event)
+		return
+	}
+	if r.URL.Query().Get("type") == helix.EventSubTypeChannelPollProgress {
+		a.eventsubManager.HandlePollProgress(event)
+		return
+	}
+	if r.URL.Query().Get("type") == helix.EventSubTypeChannelPollEnd {
+		a.eventsubManager.HandlePollEnd(event)
+		return
+	}
+	if r.URL.Query().Get("type") == helix.EventSubTypeStreamOnline {
+		a.eventsubManager.HandleStreamOnline(event)
+		return
+	}
+	if r.URL.Query().Get("type") == helix.EventSubTypeStreamOffline {
+		a.eventsubManager.HandleStreamOffline(event)
+		return
+	}
+	if r.URL.Query().Get("type") == helix.EventSubTypeChannelRaid {
+		a.eventsubManager.HandleRaid(event)
+		return
+	}
 
 	http.Error(w, "Invalid event type", http.StatusBadRequest)
 }
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gempir/gempbot/internal/api"
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/messages"
+	"github.com/gempir/gempbot/internal/store"
+)
+
+const maxMessageTemplateLength = 450
+
+type messageTemplateResponse struct {
+	messages.Definition
+	Text     string
+	Disabled bool
+}
+
+func (a *Api) MessageTemplatesHandler(w http.ResponseWriter, r *http.Request) {
+	authResp, _, apiErr := a.authClient.AttemptAuth(r, w)
+	if apiErr != nil {
+		return
+	}
+	userID := authResp.Data.UserID
+
+	if r.URL.Query().Get("managing") != "" {
+		userID, apiErr = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityBotConfig)
+		if apiErr != nil {
+			http.Error(w, apiErr.Error(), apiErr.Status())
+			return
+		}
+	}
+
+	if r.Method == http.MethodGet {
+		overrides := map[string]store.MessageTemplate{}
+		for _, template := range a.db.GetMessageTemplates(userID) {
+			overrides[template.Key] = template
+		}
+
+		resp := []messageTemplateResponse{}
+		for _, definition := range messages.Definitions() {
+			override := overrides[string(definition.Key)]
+			resp = append(resp, messageTemplateResponse{Definition: definition, Text: override.Text, Disabled: override.Disabled})
+		}
+
+		api.WriteJson(w, resp, http.StatusOK)
+		return
+	} else if r.Method == http.MethodPost {
+		var template store.MessageTemplate
+		if err := json.NewDecoder(r.Body).Decode(&template); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if _, ok := messages.GetDefinition(messages.Key(template.Key)); !ok {
+			http.Error(w, "unknown message key", http.StatusBadRequest)
+			return
+		}
+		template.Text = strings.TrimSpace(template.Text)
+		if template.Text == "" && !template.Disabled {
+			http.Error(w, "text is required unless the message is disabled", http.StatusBadRequest)
+			return
+		}
+		if len(template.Text) > maxMessageTemplateLength || strings.HasPrefix(template.Text, "/") || strings.HasPrefix(template.Text, ".") {
+			http.Error(w, "text must be at most 450 characters and not start with / or .", http.StatusBadRequest)
+			return
+		}
+		template.ChannelTwitchID = userID
+
+		err := a.db.SaveMessageTemplate(r.Context(), template)
+		if err != nil {
+			log.Error(err)
+			http.Error(w, "failed to save message template", http.StatusInternalServerError)
+			return
+		}
+		a.bot.Messenger.LoadChannel(userID)
+
+		api.WriteJson(w, "ok", http.StatusOK)
+		return
+	} else if r.Method == http.MethodDelete {
+		err := a.db.DeleteMessageTemplate(r.Context(), userID, r.URL.Query().Get("key"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		a.bot.Messenger.LoadChannel(userID)
+
+		api.WriteJson(w, "ok", http.StatusOK)
+		return
+	}
+
+	http.Error(w, "unknown method", http.StatusMethodNotAllowed)
+}
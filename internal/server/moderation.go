@@ -0,0 +1,145 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gempir/gempbot/internal/api"
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/store"
+)
+
+const (
+	maxBannedPhraseLength = 200
+	maxTimeoutSeconds     = 1209600
+	maxPermitSeconds      = 3600
+)
+
+func (a *Api) ModerationHandler(w http.ResponseWriter, r *http.Request) {
+	authResp, _, apiErr := a.authClient.AttemptAuth(r, w)
+	if apiErr != nil {
+		return
+	}
+	userID := authResp.Data.UserID
+
+	if r.URL.Query().Get("managing") != "" {
+		userID, apiErr = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityModeration)
+		if apiErr != nil {
+			http.Error(w, apiErr.Error(), apiErr.Status())
+			return
+		}
+	}
+
+	if r.Method == http.MethodGet {
+		cfg, _ := a.db.GetModerationConfig(userID)
+		api.WriteJson(w, cfg, http.StatusOK)
+		return
+	} else if r.Method == http.MethodPost {
+		var cfg store.ModerationConfig
+		if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if cfg.TimeoutSeconds < 0 || cfg.TimeoutSeconds > maxTimeoutSeconds {
+			http.Error(w, "timeout must be between 0 and 1209600 seconds", http.StatusBadRequest)
+			return
+		}
+		if cfg.PermitSeconds < 0 || cfg.PermitSeconds > maxPermitSeconds {
+			http.Error(w, "permit must be between 0 and 3600 seconds", http.StatusBadRequest)
+			return
+		}
+		if cfg.BanAfterStrikes < 0 {
+			http.Error(w, "strikes can't be negative", http.StatusBadRequest)
+			return
+		}
+		cfg.ChannelTwitchID = userID
+
+		err := a.db.SaveModerationConfig(r.Context(), cfg)
+		if err != nil {
+			log.Error(err)
+			http.Error(w, "failed to save moderation config", http.StatusInternalServerError)
+			return
+		}
+		a.moderator.LoadChannel(userID)
+
+		api.WriteJson(w, "ok", http.StatusOK)
+		return
+	}
+
+	http.Error(w, "unknown method", http.StatusMethodNotAllowed)
+}
+
+func (a *Api) BannedPhrasesHandler(w http.ResponseWriter, r *http.Request) {
+	authResp, _, apiErr := a.authClient.AttemptAuth(r, w)
+	if apiErr != nil {
+		return
+	}
+	userID := authResp.Data.UserID
+
+	if r.URL.Query().Get("managing") != "" {
+		userID, apiErr = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityModeration)
+		if apiErr != nil {
+			http.Error(w, apiErr.Error(), apiErr.Status())
+			return
+		}
+	}
+
+	if r.Method == http.MethodGet {
+		api.WriteJson(w, a.db.GetBannedPhrases(userID), http.StatusOK)
+		return
+	} else if r.Method == http.MethodPost {
+		var phrase store.BannedPhrase
+		if err := json.NewDecoder(r.Body).Decode(&phrase); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		phrase.Phrase = strings.TrimSpace(phrase.Phrase)
+		if phrase.Phrase == "" || len(phrase.Phrase) > maxBannedPhraseLength {
+			http.Error(w, "phrase must be between 1 and 200 characters", http.StatusBadRequest)
+			return
+		}
+		if phrase.Regex {
+			if _, err := regexp.Compile(phrase.Phrase); err != nil {
+				http.Error(w, "invalid regex: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		phrase.ID = 0
+		phrase.ChannelTwitchID = userID
+
+		phrase, err := a.db.CreateBannedPhrase(r.Context(), phrase)
+		if err != nil {
+			log.Error(err)
+			http.Error(w, "failed to save phrase", http.StatusInternalServerError)
+			return
+		}
+		a.moderator.LoadChannel(userID)
+
+		api.WriteJson(w, phrase, http.StatusOK)
+		return
+	} else if r.Method == http.MethodDelete {
+		id, err := strconv.ParseUint(r.URL.Query().Get("id"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+
+		err = a.db.DeleteBannedPhrase(r.Context(), userID, uint(id))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		a.moderator.LoadChannel(userID)
+
+		api.WriteJson(w, "ok", http.StatusOK)
+		return
+	}
+
+	http.Error(w, "unknown method", http.StatusMethodNotAllowed)
+}
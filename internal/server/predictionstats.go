@@ -0,0 +1,54 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gempir/gempbot/internal/api"
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/stats"
+)
+
+const (
+	defaultStatsDays = 30
+	maxStatsDays     = 365
+	topPredictors    = 10
+)
+
+func (a *Api) PredictionStatsHandler(w http.ResponseWriter, r *http.Request) {
+	authResp, _, apiErr := a.authClient.AttemptAuth(r, w)
+	if apiErr != nil {
+		return
+	}
+	userID := authResp.Data.UserID
+
+	if r.URL.Query().Get("managing") != "" {
+		userID, apiErr = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityPredictions)
+		if apiErr != nil {
+			http.Error(w, apiErr.Error(), apiErr.Status())
+			return
+		}
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "unknown method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	days := defaultStatsDays
+	if r.URL.Query().Get("days") != "" {
+		var err error
+		days, err = strconv.Atoi(r.URL.Query().Get("days"))
+		if err != nil || days < 1 || days > maxStatsDays {
+			http.Error(w, "days must be between 1 and 365", http.StatusBadRequest)
+			return
+		}
+	}
+	since := time.Now().AddDate(0, 0, -days)
+
+	predictionStats := stats.CalculatePredictionStats(a.db.GetPredictionsSince(r.Context(), userID, since))
+	predictionStats.TopPredictors = a.db.GetTopPredictors(r.Context(), userID, since, topPredictors)
+
+	api.WriteJson(w, predictionStats, http.StatusOK)
+}
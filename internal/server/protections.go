@@ -0,0 +1,88 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gempir/gempbot/internal/api"
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/store"
+)
+
+func (a *Api) ProtectionsHandler(w http.ResponseWriter, r *http.Request) {
+	authResp, _, apiErr := a.authClient.AttemptAuth(r, w)
+	if apiErr != nil {
+		return
+	}
+	userID := authResp.Data.UserID
+
+	if r.URL.Query().Get("managing") != "" {
+		userID, apiErr = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityBlocks)
+		if apiErr != nil {
+			http.Error(w, apiErr.Error(), apiErr.Status())
+			return
+		}
+	}
+
+	if r.Method == http.MethodGet {
+		api.WriteJson(w, a.db.GetEmoteProtections(userID), http.StatusOK)
+		return
+	}
+	if r.Method == http.MethodPatch {
+		var req protectRequest
+
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.EmoteType != dto.REWARD_SEVENTV && req.EmoteType != dto.REWARD_BTTV {
+			http.Error(w, "unknown emote type", http.StatusBadRequest)
+			return
+		}
+
+		toProtect := []string{}
+		for _, emote := range strings.Split(req.Emotes, ",") {
+			emote = strings.TrimSpace(emote)
+			if emote != "" {
+				toProtect = append(toProtect, emote)
+			}
+		}
+		if len(toProtect) == 0 {
+			http.Error(w, "no emotes to protect", http.StatusBadRequest)
+			return
+		}
+
+		err = a.db.ProtectEmotes(userID, toProtect, req.EmoteType)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		return
+	}
+	if r.Method == http.MethodDelete {
+		var req store.EmoteProtection
+
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = a.db.DeleteEmoteProtection(userID, req.Emote, req.Type)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		return
+	}
+
+	http.Error(w, "unknown method", http.StatusMethodNotAllowed)
+}
+
+type protectRequest struct {
+	// Emotes are comma separated emote IDs or codes
+	Emotes    string         `json:"emotes"`
+	EmoteType dto.RewardType `json:"type"`
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gempir/gempbot/internal/api"
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/store"
+)
+
+func (a *Api) QuotesHandler(w http.ResponseWriter, r *http.Request) {
+	authResp, _, apiErr := a.authClient.AttemptAuth(r, w)
+	if apiErr != nil {
+		return
+	}
+	userID := authResp.Data.UserID
+
+	if r.URL.Query().Get("managing") != "" {
+		userID, apiErr = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityCommands)
+		if apiErr != nil {
+			http.Error(w, apiErr.Error(), apiErr.Status())
+			return
+		}
+	}
+
+	if r.Method == http.MethodGet {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+
+		pageNumber, err := strconv.Atoi(page)
+		if err != nil || pageNumber < 1 {
+			http.Error(w, "invalid page", http.StatusBadRequest)
+			return
+		}
+
+		api.WriteJson(w, a.db.GetQuotes(r.Context(), userID, r.URL.Query().Get("search"), pageNumber, 20), http.StatusOK)
+		return
+	}
+	if r.Method == http.MethodPost {
+		var quote store.Quote
+		if err := json.NewDecoder(r.Body).Decode(&quote); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		quote.Text = strings.TrimLeft(strings.TrimSpace(quote.Text), "/.")
+		if quote.Text == "" || len(quote.Text) > dto.MaxQuoteLength {
+			http.Error(w, fmt.Sprintf("quote must be between 1 and %d characters", dto.MaxQuoteLength), http.StatusBadRequest)
+			return
+		}
+		quote.ChannelTwitchID = userID
+		quote.AuthorTwitchID = authResp.Data.UserID
+		quote.AuthorName = authResp.Data.Login
+		quote.Category = strings.TrimSpace(quote.Category)
+
+		quote, err := a.db.CreateQuote(r.Context(), quote)
+		if err != nil {
+			log.Error(err)
+			http.Error(w, "failed to save quote", http.StatusInternalServerError)
+			return
+		}
+
+		api.WriteJson(w, quote, http.StatusOK)
+		return
+	}
+	if r.Method == http.MethodDelete {
+		number, err := strconv.Atoi(r.URL.Query().Get("number"))
+		if err != nil {
+			http.Error(w, "invalid number", http.StatusBadRequest)
+			return
+		}
+
+		err = a.db.DeleteQuote(r.Context(), userID, number)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		api.WriteJson(w, "ok", http.StatusOK)
+		return
+	}
+
+	http.Error(w, "unknown method", http.StatusMethodNotAllowed)
+}
@@ -18,7 +18,7 @@ func (a *Api) RewardHandler(w http.ResponseWriter, r *http.Request) {
 	userID := authResp.Data.UserID
 
 	if r.URL.Query().Get("managing") != "" {
-		userID, apiErr = a.userAdmin.CheckEditor(r, a.userAdmin.GetUserConfig(userID))
+		userID, apiErr = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityRewards)
 		if apiErr != nil {
 			http.Error(w, apiErr.Error(), apiErr.Status())
 			return
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gempir/gempbot/internal/api"
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/store"
+)
+
+type StreamSessionDetails struct {
+	Session      store.StreamSession   `json:"session"`
+	EmoteChanges []store.EmoteAdd      `json:"emoteChanges"`
+	Predictions  []store.PredictionLog `json:"predictions"`
+	Media        []store.MediaQueue    `json:"media"`
+}
+
+// StreamSessionsHandler lists the streams of a channel, with ?id= it returns one stream and everything that happened in it.
+// id=current is the stream that is live right now.
+func (a *Api) StreamSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	authResp, _, apiErr := a.authClient.AttemptAuth(r, w)
+	if apiErr != nil {
+		return
+	}
+	userID := authResp.Data.UserID
+
+	if r.URL.Query().Get("managing") != "" {
+		userID, apiErr = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityBotConfig)
+		if apiErr != nil {
+			http.Error(w, apiErr.Error(), apiErr.Status())
+			return
+		}
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "unknown method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if id := r.URL.Query().Get("id"); id != "" {
+		var sessionID uint
+		if id == "current" {
+			sessionID = a.db.GetActiveStreamSessionID(userID)
+		} else {
+			parsed, err := strconv.ParseUint(id, 10, 64)
+			if err != nil {
+				http.Error(w, "invalid id", http.StatusBadRequest)
+				return
+			}
+			sessionID = uint(parsed)
+		}
+
+		session, err := a.db.GetStreamSession(r.Context(), userID, sessionID)
+		if err != nil {
+			http.Error(w, "stream not found", http.StatusNotFound)
+			return
+		}
+
+		api.WriteJson(w, StreamSessionDetails{
+			Session:      session,
+			EmoteChanges: a.db.GetStreamSessionEmoteChanges(r.Context(), session.ID),
+			Predictions:  a.db.GetStreamSessionPredictions(r.Context(), session.ID),
+			Media:        a.db.GetStreamSessionMedia(r.Context(), session.ID),
+		}, http.StatusOK)
+		return
+	}
+
+	page := r.URL.Query().Get("page")
+	if page == "" {
+		page = "1"
+	}
+
+	pageNumber, err := strconv.Atoi(page)
+	if err != nil || pageNumber < 1 {
+		http.Error(w, "invalid page", http.StatusBadRequest)
+		return
+	}
+
+	api.WriteJson(w, a.db.GetStreamSessions(r.Context(), userID, pageNumber, 20), http.StatusOK)
+}
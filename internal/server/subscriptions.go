@@ -4,13 +4,17 @@ import (
 	"net/http"
 
 	"github.com/gempir/gempbot/internal/api"
+	"github.com/gempir/gempbot/internal/dto"
 	"github.com/gempir/gempbot/internal/log"
 )
 
 type SubscribtionStatus struct {
 	Predictions bool `json:"predictions"`
+	Polls       bool `json:"polls"`
 }
 
+const subscriptionTypePolls = "polls"
+
 func (a *Api) SubscriptionsHandler(w http.ResponseWriter, r *http.Request) {
 	authResp, _, apiErr := a.authClient.AttemptAuth(r, w)
 	if apiErr != nil {
@@ -19,19 +23,31 @@ func (a *Api) SubscriptionsHandler(w http.ResponseWriter, r *http.Request) {
 	userID := authResp.Data.UserID
 
 	if r.URL.Query().Get("managing") != "" {
-		userID, apiErr = a.userAdmin.CheckEditor(r, a.userAdmin.GetUserConfig(userID))
+		userID, apiErr = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityPredictions)
 		if apiErr != nil {
 			http.Error(w, apiErr.Error(), apiErr.Status())
 			return
 		}
 	}
 
+	// type=polls toggles the poll subscriptions, predictions are the default
+	polls := r.URL.Query().Get("type") == subscriptionTypePolls
+
 	if r.Method == http.MethodPut {
-		a.eventsubManager.SubscribePredictions(userID)
+		if polls {
+			a.eventsubManager.SubscribePolls(userID)
+		} else {
+			a.eventsubManager.SubscribePredictions(userID)
+		}
 
 		api.WriteJson(w, "ok", http.StatusOK)
 	} else if r.Method == http.MethodDelete {
-		for _, sub := range a.db.GetAllPredictionSubscriptions(userID) {
+		subs := a.db.GetAllPredictionSubscriptions(userID)
+		if polls {
+			subs = a.db.GetAllPollSubscriptions(userID)
+		}
+
+		for _, sub := range subs {
 			log.Infof("Removing subscribtion on request %s from %s", sub.SubscriptionID, sub.TargetTwitchID)
 			err := a.eventsubManager.RemoveEventSubSubscription(sub.SubscriptionID)
 			if err != nil {
@@ -45,7 +61,8 @@ func (a *Api) SubscriptionsHandler(w http.ResponseWriter, r *http.Request) {
 		log.Info(subs)
 
 		hasPredictions := len(subs) > 0
+		hasPolls := len(a.db.GetAllPollSubscriptions(userID)) > 0
 
-		api.WriteJson(w, SubscribtionStatus{Predictions: hasPredictions}, http.StatusOK)
+		api.WriteJson(w, SubscribtionStatus{Predictions: hasPredictions, Polls: hasPolls}, http.StatusOK)
 	}
 }
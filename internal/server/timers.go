@@ -0,0 +1,93 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gempir/gempbot/internal/api"
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/store"
+)
+
+const (
+	minTimerInterval = 5
+	maxTimerInterval = 1440
+	maxTimerLines    = 1000
+)
+
+func (a *Api) TimersHandler(w http.ResponseWriter, r *http.Request) {
+	authResp, _, apiErr := a.authClient.AttemptAuth(r, w)
+	if apiErr != nil {
+		return
+	}
+	userID := authResp.Data.UserID
+
+	if r.URL.Query().Get("managing") != "" {
+		userID, apiErr = a.userAdmin.CheckPermission(r, a.userAdmin.GetUserConfig(userID), dto.CapabilityCommands)
+		if apiErr != nil {
+			http.Error(w, apiErr.Error(), apiErr.Status())
+			return
+		}
+	}
+
+	if r.Method == http.MethodGet {
+		api.WriteJson(w, a.db.GetTimers(userID), http.StatusOK)
+		return
+	} else if r.Method == http.MethodPost {
+		var timer store.Timer
+		if err := json.NewDecoder(r.Body).Decode(&timer); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		timer.Message = strings.TrimSpace(timer.Message)
+		if timer.Message == "" || len(timer.Message) > 500 {
+			http.Error(w, "message must be between 1 and 500 characters", http.StatusBadRequest)
+			return
+		}
+		if timer.IntervalMinutes < minTimerInterval || timer.IntervalMinutes > maxTimerInterval {
+			http.Error(w, "interval must be between 5 and 1440 minutes", http.StatusBadRequest)
+			return
+		}
+		if timer.MinChatLines < 0 || timer.MinChatLines > maxTimerLines {
+			http.Error(w, "chat lines must be between 0 and 1000", http.StatusBadRequest)
+			return
+		}
+		timer.ChannelTwitchID = userID
+
+		timer, err := a.db.SaveTimer(r.Context(), timer)
+		if err != nil {
+			log.Error(err)
+			http.Error(w, "failed to save timer", http.StatusInternalServerError)
+			return
+		}
+
+		a.eventsubManager.SubscribeStreamStatus(userID)
+		a.timerScheduler.ReloadChannel(userID)
+
+		api.WriteJson(w, timer, http.StatusOK)
+		return
+	} else if r.Method == http.MethodDelete {
+		id, err := strconv.ParseUint(r.URL.Query().Get("id"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+
+		err = a.db.DeleteTimer(r.Context(), userID, uint(id))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		a.timerScheduler.ReloadChannel(userID)
+
+		api.WriteJson(w, "ok", http.StatusOK)
+		return
+	}
+
+	http.Error(w, "unknown method", http.StatusMethodNotAllowed)
+}
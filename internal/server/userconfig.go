@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/gempir/gempbot/internal/api"
+	"github.com/gempir/gempbot/internal/dto"
 	"github.com/gempir/gempbot/internal/log"
 	"github.com/gempir/gempbot/internal/user"
 )
@@ -20,16 +21,20 @@ func (a *Api) UserConfigHandler(w http.ResponseWriter, r *http.Request) {
 		userConfig := a.userAdmin.GetUserConfig(authResp.Data.UserID)
 
 		if r.URL.Query().Get("managing") != "" {
-			ownerUserID, err := a.userAdmin.CheckEditor(r, userConfig)
+			ownerUserID, err := a.userAdmin.CheckPermission(r, userConfig, 0)
 			if err != nil {
 				http.Error(w, err.Error(), err.Status())
 				return
 			}
+			canManagePermissions := userConfig.HasCapabilityFor(ownerUserID, dto.CapabilityPermissions)
 
 			editorFor := userConfig.Protected.EditorFor
 			userConfig = a.userAdmin.GetUserConfig(ownerUserID)
 
 			userConfig.Protected.EditorFor = editorFor
+			if !canManagePermissions {
+				userConfig.Permissions = map[string]user.Permission{}
+			}
 		}
 
 		userConfig, err := a.userAdmin.ConvertUserConfig(userConfig, true)
@@ -56,12 +61,13 @@ func (a *Api) UserConfigHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		err = a.userAdmin.ProcessConfig(r.Context(), authResp.Data.UserID, authResp.Data.Login, newConfig, r.URL.Query().Get("managing"))
+		ownerUserID, err := a.userAdmin.ProcessConfig(r.Context(), authResp.Data.UserID, authResp.Data.Login, newConfig, r.URL.Query().Get("managing"))
 		if err != nil {
 			log.Errorf("failed processing config: %s", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
+		a.bot.ReloadChannelConfig(ownerUserID)
 
 		api.WriteJson(w, nil, http.StatusOK)
 		return
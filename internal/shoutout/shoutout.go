@@ -0,0 +1,129 @@
+package shoutout
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/helixclient"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/messages"
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/nicklaw5/helix/v2"
+)
+
+const CmdNameShoutout = "so"
+
+type storage interface {
+	GetBotConfig(userID string) (store.BotConfig, error)
+}
+
+type shoutoutBot interface {
+	RegisterCommand(command dto.Command)
+}
+
+// Shouter sends official Twitch shoutouts with an announcement in chat
+type Shouter struct {
+	db          storage
+	helixClient helixclient.Client
+	messenger   *messages.Messenger
+}
+
+func NewShouter(db storage, helixClient helixclient.Client, bot shoutoutBot, messenger *messages.Messenger) *Shouter {
+	s := &Shouter{
+		db:          db,
+		helixClient: helixClient,
+		messenger:   messenger,
+	}
+
+	bot.RegisterCommand(dto.Command{
+		Name:        CmdNameShoutout,
+		Description: "Shouts out another streamer",
+		Usage:       CmdNameShoutout + " <user>",
+		Capability:  dto.CapabilityModeration,
+		Handler:     s.handleShoutout,
+	})
+
+	return s
+}
+
+// handleShoutout handles "!so <user>"
+func (s *Shouter) handleShoutout(payload dto.CommandPayload) {
+	login := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(payload.Query), "@"))
+	if login == "" {
+		s.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.CommandUsage, messages.Values{"user": payload.Msg.User.DisplayName, "usage": payload.Prefix + CmdNameShoutout + " <user>"})
+		return
+	}
+
+	target, err := s.helixClient.GetUserByUsername(login)
+	if err != nil || target.ID == "" {
+		s.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.ShoutoutNotFound, messages.Values{"user": payload.Msg.User.DisplayName, "login": login})
+		return
+	}
+
+	err = s.Shoutout(payload.Msg.RoomID, payload.Msg.Channel, target)
+	if err != nil {
+		s.messenger.Say(payload.Msg.RoomID, payload.Msg.Channel, messages.ShoutoutFailed, messages.Values{"user": payload.Msg.User.DisplayName, "error": err.Error()})
+	}
+}
+
+// HandleRaid shouts out the raider when the channel enabled automatic shoutouts
+func (s *Shouter) HandleRaid(raid helix.EventSubChannelRaidEvent) {
+	cfg, err := s.db.GetBotConfig(raid.ToBroadcasterUserID)
+	if err != nil || !cfg.AutoShoutoutRaids {
+		return
+	}
+
+	target := helixclient.UserData{
+		ID:          raid.FromBroadcasterUserID,
+		Login:       raid.FromBroadcasterUserLogin,
+		DisplayName: raid.FromBroadcasterUserName,
+	}
+
+	err = s.Shoutout(raid.ToBroadcasterUserID, raid.ToBroadcasterUserLogin, target)
+	if err != nil {
+		log.Errorf("[%s] failed raid shoutout for %s: %s", raid.ToBroadcasterUserLogin, raid.FromBroadcasterUserLogin, err)
+	}
+}
+
+// Shoutout announces the target in chat unless the channel silenced it and sends the official shoutout, which Twitch rate limits
+func (s *Shouter) Shoutout(channelID string, channelLogin string, target helixclient.UserData) error {
+	info, err := s.helixClient.GetChannelInformation(target.ID)
+	if err != nil {
+		log.Errorf("failed to get channel information %s: %s", target.ID, err)
+	}
+
+	message, ok := s.messenger.Render(channelID, messages.ShoutoutMessage, shoutoutValues(target, info))
+	if ok {
+		err = s.helixClient.SendChatAnnouncement(channelID, message)
+		if err != nil {
+			log.Errorf("[%s] failed to send shoutout announcement: %s", channelLogin, err)
+			s.messenger.Say(channelID, channelLogin, messages.ShoutoutMessage, shoutoutValues(target, info))
+		}
+	}
+
+	err = s.helixClient.SendShoutout(channelID, target.ID)
+	if err != nil {
+		return fmt.Errorf("official shoutout failed: %s", err)
+	}
+
+	return nil
+}
+
+func shoutoutValues(target helixclient.UserData, info helix.ChannelInformation) messages.Values {
+	name := target.DisplayName
+	if name == "" {
+		name = target.Login
+	}
+	category := info.GameName
+	if category == "" {
+		category = "something"
+	}
+
+	return messages.Values{
+		"user":     name,
+		"login":    target.Login,
+		"category": category,
+		"title":    info.Title,
+	}
+}
@@ -0,0 +1,28 @@
+package shoutout
+
+import (
+	"testing"
+
+	"github.com/gempir/gempbot/internal/helixclient"
+	"github.com/gempir/gempbot/internal/messages"
+	"github.com/nicklaw5/helix/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShoutoutValues(t *testing.T) {
+	target := helixclient.UserData{Login: "gempir", DisplayName: "Gempir"}
+	definition, _ := messages.GetDefinition(messages.ShoutoutMessage)
+
+	assert.Equal(t,
+		"Go check out Gempir at twitch.tv/gempir, they were last playing Factorio!",
+		messages.Format(definition.Default, shoutoutValues(target, helix.ChannelInformation{GameName: "Factorio"})),
+	)
+	assert.Equal(t,
+		"Go check out Gempir at twitch.tv/gempir, they were last playing something!",
+		messages.Format(definition.Default, shoutoutValues(target, helix.ChannelInformation{})),
+	)
+	assert.Equal(t,
+		"gempir: building factories",
+		messages.Format("{login}: {title}", shoutoutValues(helixclient.UserData{Login: "gempir"}, helix.ChannelInformation{Title: "building factories"})),
+	)
+}
@@ -0,0 +1,121 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/store"
+)
+
+type PredictionStats struct {
+	Total                int
+	Resolved             int
+	Canceled             int
+	ChannelPointsWagered int
+	AverageChannelPoints float64
+	AverageParticipants  float64
+	BlueWins             int
+	PinkWins             int
+	BlueWinRate          float64
+	PinkWinRate          float64
+	LongestBlueStreak    int
+	LongestPinkStreak    int
+	Predictions          []PredictionSummary
+	TopPredictors        []store.TopPredictor
+}
+
+type PredictionSummary struct {
+	ID            string
+	Title         string
+	Status        string
+	StartedAt     time.Time
+	Participants  int
+	ChannelPoints int
+	WinningColor  string
+}
+
+// CalculatePredictionStats expects the predictions ordered oldest first, running predictions are skipped
+func CalculatePredictionStats(predictions []store.PredictionLog) PredictionStats {
+	stats := PredictionStats{Predictions: []PredictionSummary{}}
+
+	participants := 0
+	blueStreak, pinkStreak := 0, 0
+	for _, prediction := range predictions {
+		if prediction.Status != dto.PredictionStatusResolved && prediction.Status != dto.PredictionStatusCanceled {
+			continue
+		}
+
+		summary := PredictionSummary{
+			ID:        prediction.ID,
+			Title:     prediction.Title,
+			Status:    prediction.Status,
+			StartedAt: prediction.StartedAt,
+		}
+		for _, outcome := range prediction.Outcomes {
+			summary.Participants += outcome.Users
+			summary.ChannelPoints += outcome.ChannelPoints
+		}
+
+		stats.Total++
+		if prediction.Status == dto.PredictionStatusCanceled {
+			stats.Canceled++
+			stats.Predictions = append(stats.Predictions, summary)
+			continue
+		}
+
+		stats.Resolved++
+		stats.ChannelPointsWagered += summary.ChannelPoints
+		participants += summary.Participants
+
+		summary.WinningColor = winningColor(prediction)
+		switch summary.WinningColor {
+		case dto.Outcome_First:
+			stats.BlueWins++
+			blueStreak++
+			pinkStreak = 0
+		case dto.Outcome_Second:
+			stats.PinkWins++
+			pinkStreak++
+			blueStreak = 0
+		}
+		if blueStreak > stats.LongestBlueStreak {
+			stats.LongestBlueStreak = blueStreak
+		}
+		if pinkStreak > stats.LongestPinkStreak {
+			stats.LongestPinkStreak = pinkStreak
+		}
+
+		stats.Predictions = append(stats.Predictions, summary)
+	}
+
+	if stats.Resolved > 0 {
+		stats.AverageChannelPoints = float64(stats.ChannelPointsWagered) / float64(stats.Resolved)
+		stats.AverageParticipants = float64(participants) / float64(stats.Resolved)
+	}
+	if decided := stats.BlueWins + stats.PinkWins; decided > 0 {
+		stats.BlueWinRate = float64(stats.BlueWins) / float64(decided)
+		stats.PinkWinRate = float64(stats.PinkWins) / float64(decided)
+	}
+
+	return stats
+}
+
+// winningColor is only blue or pink for predictions with 2 outcomes, twitch colors every outcome blue otherwise
+func winningColor(prediction store.PredictionLog) string {
+	if len(prediction.Outcomes) != 2 {
+		return ""
+	}
+
+	for index, outcome := range prediction.Outcomes {
+		if outcome.ID != prediction.WinningOutcomeID {
+			continue
+		}
+		if index == 0 {
+			return dto.Outcome_First
+		}
+
+		return dto.Outcome_Second
+	}
+
+	return ""
+}
@@ -0,0 +1,66 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPrediction(id string, status string, winner int, blue, pink store.PredictionLogOutcome) store.PredictionLog {
+	blue.ID = id + "-blue"
+	pink.ID = id + "-pink"
+
+	prediction := store.PredictionLog{ID: id, Status: status, Outcomes: []store.PredictionLogOutcome{blue, pink}}
+	if winner >= 0 {
+		prediction.WinningOutcomeID = prediction.Outcomes[winner].ID
+	}
+
+	return prediction
+}
+
+func TestCalculatePredictionStats(t *testing.T) {
+	predictions := []store.PredictionLog{
+		newPrediction("1", dto.PredictionStatusResolved, 0, store.PredictionLogOutcome{Users: 3, ChannelPoints: 100}, store.PredictionLogOutcome{Users: 1, ChannelPoints: 50}),
+		newPrediction("2", dto.PredictionStatusResolved, 0, store.PredictionLogOutcome{Users: 2, ChannelPoints: 200}, store.PredictionLogOutcome{Users: 2, ChannelPoints: 0}),
+		newPrediction("3", dto.PredictionStatusCanceled, -1, store.PredictionLogOutcome{Users: 10, ChannelPoints: 1000}, store.PredictionLogOutcome{}),
+		newPrediction("4", dto.PredictionStatusResolved, 1, store.PredictionLogOutcome{Users: 0, ChannelPoints: 0}, store.PredictionLogOutcome{Users: 4, ChannelPoints: 150}),
+		newPrediction("5", dto.PredictionStatusResolved, 0, store.PredictionLogOutcome{Users: 4, ChannelPoints: 100}, store.PredictionLogOutcome{}),
+		newPrediction("6", dto.PredictionStatusActive, -1, store.PredictionLogOutcome{Users: 100}, store.PredictionLogOutcome{}),
+	}
+
+	stats := CalculatePredictionStats(predictions)
+
+	assert.Equal(t, 5, stats.Total)
+	assert.Equal(t, 4, stats.Resolved)
+	assert.Equal(t, 1, stats.Canceled)
+	assert.Equal(t, 600, stats.ChannelPointsWagered)
+	assert.Equal(t, 150.0, stats.AverageChannelPoints)
+	assert.Equal(t, 4.0, stats.AverageParticipants)
+	assert.Equal(t, 3, stats.BlueWins)
+	assert.Equal(t, 1, stats.PinkWins)
+	assert.Equal(t, 0.75, stats.BlueWinRate)
+	assert.Equal(t, 0.25, stats.PinkWinRate)
+	assert.Equal(t, 2, stats.LongestBlueStreak)
+	assert.Equal(t, 1, stats.LongestPinkStreak)
+	assert.Len(t, stats.Predictions, 5)
+	assert.Equal(t, dto.Outcome_Second, stats.Predictions[3].WinningColor)
+}
+
+func TestCalculatePredictionStatsEmpty(t *testing.T) {
+	stats := CalculatePredictionStats([]store.PredictionLog{})
+
+	assert.Equal(t, 0, stats.Total)
+	assert.Equal(t, 0.0, stats.BlueWinRate)
+	assert.Empty(t, stats.Predictions)
+}
+
+func TestWinningColorWithMoreOutcomes(t *testing.T) {
+	prediction := store.PredictionLog{
+		WinningOutcomeID: "b",
+		Outcomes:         []store.PredictionLogOutcome{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+	}
+
+	assert.Equal(t, "", winningColor(prediction))
+}
@@ -8,9 +8,17 @@ import (
 )
 
 type BotConfig struct {
-	OwnerTwitchID string `gorm:"primaryKey"`
-	JoinBot       bool   `gorm:"index"`
-	MediaCommands bool
+	OwnerTwitchID     string `gorm:"primaryKey"`
+	JoinBot           bool   `gorm:"index"`
+	MediaCommands     bool
+	CommandPrefix     string
+	AutoShoutoutRaids bool
+	// JoinFailure is set when the bot was banned or the channel suspended, we don't join again until the config is saved
+	JoinFailure string
+	// DryRun only logs and records side effects like chat messages, emote changes and predictions
+	DryRun bool `gorm:"index"`
+	// ChatLogs persists the chat messages of the channel until the retention period ends
+	ChatLogs bool `gorm:"index"`
 }
 
 func (db *Database) SaveBotConfig(ctx context.Context, botCfg BotConfig) error {
@@ -21,6 +29,15 @@ func (db *Database) SaveBotConfig(ctx context.Context, botCfg BotConfig) error {
 	return update.Error
 }
 
+// SetBotConfigJoinFailure only touches configs with a different failure, saving the bot config clears it
+func (db *Database) SetBotConfigJoinFailure(ctx context.Context, ownerTwitchID string, failure string) error {
+	update := db.Client.WithContext(ctx).Model(&BotConfig{}).
+		Where("owner_twitch_id = ? AND join_failure <> ?", ownerTwitchID, failure).
+		Update("join_failure", failure)
+
+	return update.Error
+}
+
 func (db *Database) GetAllJoinBotConfigs() []BotConfig {
 	var botConfigs []BotConfig
 
@@ -29,6 +46,22 @@ func (db *Database) GetAllJoinBotConfigs() []BotConfig {
 	return botConfigs
 }
 
+func (db *Database) GetAllDryRunBotConfigs() []BotConfig {
+	var botConfigs []BotConfig
+
+	db.Client.Where("dry_run = true").Find(&botConfigs)
+
+	return botConfigs
+}
+
+func (db *Database) GetAllChatLogBotConfigs() []BotConfig {
+	var botConfigs []BotConfig
+
+	db.Client.Where("chat_logs = true").Find(&botConfigs)
+
+	return botConfigs
+}
+
 func (db *Database) GetBotConfig(userID string) (BotConfig, error) {
 	var botConfig BotConfig
 	result := db.Client.Where("owner_twitch_id = ?", userID).First(&botConfig)
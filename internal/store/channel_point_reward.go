@@ -1,6 +1,7 @@
 package store
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -59,6 +60,15 @@ func (db *Database) GetChannelPointReward(userID string, rewardType dto.RewardTy
 	return reward, nil
 }
 
+// GetEmoteChannelPointRewards returns the 7TV and BTTV rewards of all channels
+func (db *Database) GetEmoteChannelPointRewards(ctx context.Context) []ChannelPointReward {
+	var rewards []ChannelPointReward
+
+	db.Client.WithContext(ctx).Where("type IN ?", []dto.RewardType{dto.REWARD_SEVENTV, dto.REWARD_BTTV}).Find(&rewards)
+
+	return rewards
+}
+
 func (db *Database) DeleteChannelPointReward(userID string, rewardType dto.RewardType) {
 	db.Client.Where("owner_twitch_id = ? AND type = ?", userID, rewardType).Delete(&ChannelPointReward{})
 }
@@ -0,0 +1,199 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	chatMessagePartitionPrefix = "chat_messages_"
+	// chatMessageDefaultPartition takes the messages of days without a partition, so a single one doesn't fail its whole batch
+	chatMessageDefaultPartition = chatMessagePartitionPrefix + "default"
+)
+
+// ChatMessage is a logged chat message of a channel that opted in, the table is partitioned by day to drop old messages cheaply
+type ChatMessage struct {
+	ID              uint64 `gorm:"primaryKey;autoIncrement"`
+	ChannelTwitchID string
+	UserTwitchID    string
+	UserLogin       string
+	UserDisplayName string
+	MessageID       string
+	Text            string
+	CreatedAt       time.Time `gorm:"primaryKey"`
+}
+
+type ChatMessageQuery struct {
+	ChannelTwitchID string
+	// UserLogin matches the login case-insensitive
+	UserLogin string
+	From      time.Time
+	To        time.Time
+	// Text matches a substring case-insensitive
+	Text  string
+	Limit int
+}
+
+// migrateChatMessages creates the partitioned table, AutoMigrate can't create partitioned tables
+func (db *Database) migrateChatMessages() error {
+	err := db.Client.Exec(`CREATE TABLE IF NOT EXISTS chat_messages (
+		id bigserial,
+		channel_twitch_id text NOT NULL,
+		user_twitch_id text NOT NULL,
+		user_login text NOT NULL,
+		user_display_name text NOT NULL DEFAULT '',
+		message_id text NOT NULL DEFAULT '',
+		text text NOT NULL,
+		created_at timestamptz NOT NULL,
+		PRIMARY KEY (id, created_at)
+	) PARTITION BY RANGE (created_at)`).Error
+	if err != nil {
+		return err
+	}
+
+	err = db.Client.Exec("CREATE INDEX IF NOT EXISTS idx_chat_messages_channel_created_at ON chat_messages (channel_twitch_id, created_at)").Error
+	if err != nil {
+		return err
+	}
+
+	err = db.Client.Exec("CREATE TABLE IF NOT EXISTS " + chatMessageDefaultPartition + " PARTITION OF chat_messages DEFAULT").Error
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return db.EnsureChatMessagePartitions(context.Background(), now.AddDate(0, 0, -1), now.AddDate(0, 0, 1))
+}
+
+// EnsureChatMessagePartitions creates the daily partitions for every day between from and to
+func (db *Database) EnsureChatMessagePartitions(ctx context.Context, from time.Time, to time.Time) error {
+	for day := truncateDay(from); !day.After(to); day = day.AddDate(0, 0, 1) {
+		err := db.createChatMessagePartition(ctx, day)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createChatMessagePartition moves messages of the day from the default partition into the new partition,
+// postgres refuses to create a partition for rows the default partition already holds
+func (db *Database) createChatMessagePartition(ctx context.Context, day time.Time) error {
+	partition := ChatMessagePartitionName(day)
+
+	var exists bool
+	err := db.Client.WithContext(ctx).Raw("SELECT to_regclass(?) IS NOT NULL", partition).Scan(&exists).Error
+	if err != nil || exists {
+		return err
+	}
+
+	from, to := day.Format(time.RFC3339), day.AddDate(0, 0, 1).Format(time.RFC3339)
+
+	return db.Client.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(fmt.Sprintf("CREATE TABLE %s (LIKE chat_messages INCLUDING DEFAULTS)", partition)).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(fmt.Sprintf(
+			"WITH moved AS (DELETE FROM %s WHERE created_at >= ? AND created_at < ? RETURNING *) INSERT INTO %s SELECT * FROM moved",
+			chatMessageDefaultPartition, partition,
+		), from, to).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Exec(fmt.Sprintf("ALTER TABLE chat_messages ATTACH PARTITION %s FOR VALUES FROM ('%s') TO ('%s')", partition, from, to)).Error
+	})
+}
+
+// DropChatMessagePartitions drops the partitions which only contain messages before the given time and clears those messages from the default partition
+func (db *Database) DropChatMessagePartitions(ctx context.Context, before time.Time) (int, error) {
+	var partitions []string
+	err := db.Client.WithContext(ctx).Raw(`SELECT c.relname FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_class p ON p.oid = i.inhparent
+		WHERE p.relname = 'chat_messages'`).Scan(&partitions).Error
+	if err != nil {
+		return 0, err
+	}
+
+	err = db.Client.WithContext(ctx).Exec("DELETE FROM "+chatMessageDefaultPartition+" WHERE created_at < ?", before).Error
+	if err != nil {
+		return 0, err
+	}
+
+	dropped := 0
+	for _, partition := range partitions {
+		day, ok := ParseChatMessagePartitionName(partition)
+		if !ok || day.AddDate(0, 0, 1).After(before) {
+			continue
+		}
+
+		err := db.Client.WithContext(ctx).Exec("DROP TABLE IF EXISTS " + partition).Error
+		if err != nil {
+			return dropped, err
+		}
+		dropped++
+	}
+
+	return dropped, nil
+}
+
+func (db *Database) CreateChatMessages(ctx context.Context, messages []ChatMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	return db.Client.WithContext(ctx).Create(&messages).Error
+}
+
+// SearchChatMessages returns the newest matching messages first
+func (db *Database) SearchChatMessages(ctx context.Context, query ChatMessageQuery) []ChatMessage {
+	var messages []ChatMessage
+
+	tx := db.Client.WithContext(ctx).Where("channel_twitch_id = ?", query.ChannelTwitchID)
+	if query.UserLogin != "" {
+		tx = tx.Where("user_login = ?", strings.ToLower(query.UserLogin))
+	}
+	if !query.From.IsZero() {
+		tx = tx.Where("created_at >= ?", query.From)
+	}
+	if !query.To.IsZero() {
+		tx = tx.Where("created_at < ?", query.To)
+	}
+	if text := strings.TrimSpace(query.Text); text != "" {
+		tx = tx.Where("text ILIKE ?", "%"+strings.NewReplacer("%", "\\%", "_", "\\_").Replace(text)+"%")
+	}
+
+	tx.Order("created_at desc").Limit(query.Limit).Find(&messages)
+
+	return messages
+}
+
+func ChatMessagePartitionName(day time.Time) string {
+	return chatMessagePartitionPrefix + day.UTC().Format("20060102")
+}
+
+func ParseChatMessagePartitionName(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, chatMessagePartitionPrefix) {
+		return time.Time{}, false
+	}
+
+	day, err := time.Parse("20060102", strings.TrimPrefix(name, chatMessagePartitionPrefix))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return day, true
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Command struct {
+	ChannelTwitchID string `gorm:"primaryKey"`
+	Name            string `gorm:"primaryKey"`
+	Response        string
+	Uses            int `gorm:"default:0"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (db *Database) GetCommands(channelTwitchID string) []Command {
+	var commands []Command
+
+	db.Client.Where("channel_twitch_id = ?", channelTwitchID).Order("name asc").Find(&commands)
+
+	return commands
+}
+
+func (db *Database) SaveCommand(ctx context.Context, command Command) error {
+	update := db.Client.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "channel_twitch_id"}, {Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"response", "updated_at"}),
+	}).Create(&command)
+
+	return update.Error
+}
+
+func (db *Database) DeleteCommand(ctx context.Context, channelTwitchID string, name string) error {
+	res := db.Client.WithContext(ctx).Where("channel_twitch_id = ? AND name = ?", channelTwitchID, name).Delete(&Command{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errors.New("not found")
+	}
+
+	return nil
+}
+
+// IncrementCommandUses bumps the use counter and returns the new value
+func (db *Database) IncrementCommandUses(channelTwitchID string, name string) (int, error) {
+	var command Command
+	res := db.Client.Model(&command).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "uses"}}}).
+		Where("channel_twitch_id = ? AND name = ?", channelTwitchID, name).
+		UpdateColumn("uses", gorm.Expr("uses + ?", 1))
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return 0, errors.New("not found")
+	}
+
+	return command.Uses, nil
+}
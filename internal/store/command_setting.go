@@ -0,0 +1,41 @@
+package store
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+)
+
+// CommandSetting overrides the defaults of a built-in or custom command in a channel
+type CommandSetting struct {
+	ChannelTwitchID string `gorm:"primaryKey"`
+	Command         string `gorm:"primaryKey"`
+	// GlobalCooldown and UserCooldown in seconds, nil keeps the default cooldown
+	GlobalCooldown *int
+	UserCooldown   *int
+	Disabled       bool
+	// Aliases comma separated alternative names, e.g. "pred,p"
+	Aliases string
+}
+
+func (db *Database) GetCommandSettings(channelTwitchID string) []CommandSetting {
+	var settings []CommandSetting
+
+	db.Client.Where("channel_twitch_id = ?", channelTwitchID).Order("command asc").Find(&settings)
+
+	return settings
+}
+
+func (db *Database) SaveCommandSetting(ctx context.Context, setting CommandSetting) error {
+	update := db.Client.WithContext(ctx).Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&setting)
+
+	return update.Error
+}
+
+func (db *Database) DeleteCommandSetting(ctx context.Context, channelTwitchID string, command string) error {
+	res := db.Client.WithContext(ctx).Where("channel_twitch_id = ? AND command = ?", channelTwitchID, command).Delete(&CommandSetting{})
+
+	return res.Error
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/gempir/gempbot/internal/config"
 	"github.com/gempir/gempbot/internal/dto"
@@ -12,8 +13,12 @@ import (
 
 type Store interface {
 	IsEmoteBlocked(channelUserID string, emoteID string, rewardType dto.RewardType) bool
+	GetEmoteProtections(channelTwitchID string) []EmoteProtection
 	GetEmoteAdded(channelUserID string, rewardType dto.RewardType, slots int) []EmoteAdd
 	CreateEmoteAdd(channelUserId string, rewardType dto.RewardType, emoteID string, changeType dto.EmoteChangeType)
+	GetLastEmoteAdd(channelTwitchID string, addType dto.RewardType, emoteID string) (EmoteAdd, error)
+	GetEmoteUsages(ctx context.Context, channelTwitchID string, from time.Time) []EmoteUsage
+	GetExpiredEmoteAdds(ctx context.Context, channelTwitchID string, addType dto.RewardType, from time.Time, to time.Time) []EmoteAdd
 	GetUserAccessToken(userID string) (UserAccessToken, error)
 	GetAppAccessToken() (AppAccessToken, error)
 	SaveAppAccessToken(ctx context.Context, accessToken string, refreshToken string, scopes string, expiresIn int) error
@@ -28,6 +33,7 @@ type Store interface {
 	ClearNominationEmote(ctx context.Context, channelTwitchID string, emoteID string) error
 	DeleteChannelPointRewardById(userID string, rewardID string)
 	GetChannelPointReward(userID string, rewardType dto.RewardType) (ChannelPointReward, error)
+	GetEmoteChannelPointRewards(ctx context.Context) []ChannelPointReward
 	CreateNominationVote(ctx context.Context, vote NominationVote) error
 	RemoveNominationVote(ctx context.Context, vote NominationVote) error
 	GetNomination(ctx context.Context, channelTwitchID string, emoteID string) (Nomination, error)
@@ -74,14 +80,37 @@ func (db *Database) Migrate() {
 		Permission{},
 		EventSubMessage{},
 		EmoteBlock{},
+		EmoteProtection{},
 		MediaPlayer{},
 		MediaQueue{},
 		Nomination{},
 		NominationVote{},
 		NominationDownvote{},
+		Command{},
+		CommandSetting{},
+		PredictionLog{},
+		PredictionLogOutcome{},
+		PredictionLogPredictor{},
+		Timer{},
+		StreamSession{},
+		ModerationConfig{},
+		BannedPhrase{},
+		Quote{},
+		QuoteCounter{},
+		MessageTemplate{},
+		DryRunAction{},
+		EmoteUsage{},
 	)
 	if err != nil {
 		panic("Failed to migrate, " + err.Error())
 	}
+	err = db.migrateLegacyPermissions()
+	if err != nil {
+		panic("Failed to migrate permissions, " + err.Error())
+	}
+	err = db.migrateChatMessages()
+	if err != nil {
+		panic("Failed to migrate chat messages, " + err.Error())
+	}
 	log.Info("Finished migrating schema")
 }
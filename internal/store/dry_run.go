@@ -0,0 +1,32 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// DryRunAction is a side effect the bot skipped because the channel or the whole bot runs in dry-run mode
+type DryRunAction struct {
+	ID              uint   `gorm:"primarykey"`
+	ChannelTwitchID string `gorm:"index"`
+	Action          string
+	Details         string
+	CreatedAt       time.Time
+}
+
+func (db *Database) CreateDryRunAction(ctx context.Context, action DryRunAction) error {
+	return db.Client.WithContext(ctx).Create(&action).Error
+}
+
+// GetDryRunActions returns the latest actions of a channel first
+func (db *Database) GetDryRunActions(channelTwitchID string, limit int) []DryRunAction {
+	var actions []DryRunAction
+
+	db.Client.Where("channel_twitch_id = ?", channelTwitchID).Order("created_at desc").Limit(limit).Find(&actions)
+
+	return actions
+}
+
+func (db *Database) DeleteDryRunActions(ctx context.Context, channelTwitchID string) error {
+	return db.Client.WithContext(ctx).Where("channel_twitch_id = ?", channelTwitchID).Delete(&DryRunAction{}).Error
+}
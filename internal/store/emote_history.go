@@ -2,6 +2,8 @@ package store
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/gempir/gempbot/internal/dto"
 	"gorm.io/gorm"
@@ -15,6 +17,7 @@ type EmoteAdd struct {
 	ChangeType      dto.EmoteChangeType `gorm:"index"`
 	Blocked         bool                `gorm:"index"`
 	EmoteID         string
+	StreamSessionID uint `gorm:"index"`
 }
 
 func (db *Database) GetEmoteAdd(channelTwitchID string, emoteID string) *EmoteAdd {
@@ -23,6 +26,17 @@ func (db *Database) GetEmoteAdd(channelTwitchID string, emoteID string) *EmoteAd
 	return &emoteAdd
 }
 
+// GetLastEmoteAdd returns the latest time an emote was added, by reward or moderator
+func (db *Database) GetLastEmoteAdd(channelTwitchID string, addType dto.RewardType, emoteID string) (EmoteAdd, error) {
+	var emoteAdd EmoteAdd
+	result := db.Client.Where("channel_twitch_id = ? AND type = ? AND emote_id = ? AND change_type IN ?", channelTwitchID, addType, emoteID, []dto.EmoteChangeType{dto.EMOTE_ADD_ADD, dto.EMOTE_ADD_MOD_ADD}).Order("created_at desc").First(&emoteAdd)
+	if result.RowsAffected == 0 {
+		return emoteAdd, errors.New("not found")
+	}
+
+	return emoteAdd, nil
+}
+
 func (db *Database) BlockEmoteAdd(channelTwitchID string, emoteID string) {
 	db.Client.Model(&EmoteAdd{}).Where("channel_twitch_id = ? AND emote_id = ? AND change_type = ?", channelTwitchID, emoteID, dto.EMOTE_ADD_ADD).Update("blocked", true)
 }
@@ -32,10 +46,32 @@ func (db *Database) RemoveEmoteAdd(channelTwitchID string, emoteID string) {
 }
 
 func (db *Database) CreateEmoteAdd(channelTwitchID string, addType dto.RewardType, emoteID string, emoteChangeType dto.EmoteChangeType) {
-	add := EmoteAdd{ChannelTwitchID: channelTwitchID, Type: addType, EmoteID: emoteID, ChangeType: emoteChangeType}
+	add := EmoteAdd{ChannelTwitchID: channelTwitchID, Type: addType, EmoteID: emoteID, ChangeType: emoteChangeType, StreamSessionID: db.GetActiveStreamSessionID(channelTwitchID)}
 	db.Client.Create(&add)
 }
 
+// GetEmoteAddsSince returns every emote change of a channel since the given time, oldest first
+func (db *Database) GetEmoteAddsSince(ctx context.Context, channelTwitchID string, from time.Time) []EmoteAdd {
+	var emotes []EmoteAdd
+
+	db.Client.WithContext(ctx).Where("channel_twitch_id = ? AND created_at >= ?", channelTwitchID, from).Order("created_at asc").Find(&emotes)
+
+	return emotes
+}
+
+// GetExpiredEmoteAdds returns the reward adds created between from and to that are still the latest change of their emote, oldest first
+func (db *Database) GetExpiredEmoteAdds(ctx context.Context, channelTwitchID string, addType dto.RewardType, from time.Time, to time.Time) []EmoteAdd {
+	var emotes []EmoteAdd
+
+	db.Client.WithContext(ctx).
+		Where("channel_twitch_id = ? AND type = ? AND change_type = ? AND blocked = ? AND created_at >= ? AND created_at < ?", channelTwitchID, addType, dto.EMOTE_ADD_ADD, false, from, to).
+		Where("NOT EXISTS (SELECT 1 FROM emote_adds later WHERE later.channel_twitch_id = emote_adds.channel_twitch_id AND later.type = emote_adds.type AND later.emote_id = emote_adds.emote_id AND later.created_at > emote_adds.created_at AND later.deleted_at IS NULL)").
+		Order("created_at asc").
+		Find(&emotes)
+
+	return emotes
+}
+
 func (db *Database) GetEmoteAdded(channelTwitchID string, addType dto.RewardType, limit int) []EmoteAdd {
 	var emotes []EmoteAdd
 
@@ -0,0 +1,56 @@
+package store
+
+import (
+	"time"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"gorm.io/gorm/clause"
+)
+
+// EmoteProtection keeps an emote in the channel, reward removal always skips it
+type EmoteProtection struct {
+	ChannelTwitchID string         `gorm:"primarykey"`
+	Type            dto.RewardType `gorm:"primarykey"`
+	// Emote is an emote ID or an emote code, a code protects every emote with that code
+	Emote     string `gorm:"primarykey"`
+	CreatedAt time.Time
+}
+
+func (db *Database) GetEmoteProtections(channelTwitchID string) []EmoteProtection {
+	var emoteProtections []EmoteProtection
+	db.Client.Where("channel_twitch_id = ?", channelTwitchID).Order("created_at desc").Find(&emoteProtections)
+
+	return emoteProtections
+}
+
+func (db *Database) DeleteEmoteProtection(channelTwitchID string, emote string, emoteType dto.RewardType) error {
+	emoteProtection := EmoteProtection{ChannelTwitchID: channelTwitchID, Emote: emote, Type: emoteType}
+
+	res := db.Client.Delete(&emoteProtection)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	return nil
+}
+
+func (db *Database) ProtectEmotes(channelTwitchID string, emotes []string, emoteType dto.RewardType) error {
+	var emoteProtections []EmoteProtection
+	for _, emote := range emotes {
+		emoteProtection := EmoteProtection{
+			ChannelTwitchID: channelTwitchID,
+			Emote:           emote,
+			Type:            emoteType,
+		}
+		emoteProtections = append(emoteProtections, emoteProtection)
+	}
+
+	res := db.Client.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&emoteProtections)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EmoteUsage counts the chat messages using an emote of the channel per day
+type EmoteUsage struct {
+	ChannelTwitchID string         `gorm:"primaryKey"`
+	Type            dto.RewardType `gorm:"primaryKey"`
+	EmoteID         string         `gorm:"primaryKey"`
+	Day             time.Time      `gorm:"primaryKey;type:date"`
+	Code            string
+	Count           int
+}
+
+// AddEmoteUsages adds the counts to the existing counts of the day
+func (db *Database) AddEmoteUsages(ctx context.Context, usages []EmoteUsage) error {
+	if len(usages) == 0 {
+		return nil
+	}
+
+	return db.Client.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "channel_twitch_id"}, {Name: "type"}, {Name: "emote_id"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"count": gorm.Expr("emote_usages.count + excluded.count"),
+			"code":  gorm.Expr("excluded.code"),
+		}),
+	}).Create(&usages).Error
+}
+
+func (db *Database) GetEmoteUsages(ctx context.Context, channelTwitchID string, from time.Time) []EmoteUsage {
+	var usages []EmoteUsage
+
+	db.Client.WithContext(ctx).Where("channel_twitch_id = ? AND day >= ?", channelTwitchID, from).Order("day asc").Find(&usages)
+
+	return usages
+}
@@ -30,7 +30,25 @@ func (db *Database) GetAllSubscriptions() []EventSubSubscription {
 
 func (db *Database) GetAllPredictionSubscriptions(userID string) []EventSubSubscription {
 	var subs []EventSubSubscription
-	db.Client.Where("target_twitch_id = ? AND type IN (?, ?, ?)", userID, helix.EventSubTypeChannelPredictionBegin, helix.EventSubTypeChannelPredictionLock, helix.EventSubTypeChannelPredictionEnd).Find(&subs)
+	db.Client.Where("target_twitch_id = ? AND type IN (?, ?, ?, ?)", userID, helix.EventSubTypeChannelPredictionBegin, helix.EventSubTypeChannelPredictionProgress, helix.EventSubTypeChannelPredictionLock, helix.EventSubTypeChannelPredictionEnd).Find(&subs)
+	return subs
+}
+
+func (db *Database) GetAllPollSubscriptions(userID string) []EventSubSubscription {
+	var subs []EventSubSubscription
+	db.Client.Where("target_twitch_id = ? AND type IN (?, ?, ?)", userID, helix.EventSubTypeChannelPollBegin, helix.EventSubTypeChannelPollProgress, helix.EventSubTypeChannelPollEnd).Find(&subs)
+	return subs
+}
+
+func (db *Database) GetAllStreamSubscriptions(userID string) []EventSubSubscription {
+	var subs []EventSubSubscription
+	db.Client.Where("target_twitch_id = ? AND type IN (?, ?)", userID, helix.EventSubTypeStreamOnline, helix.EventSubTypeStreamOffline).Find(&subs)
+	return subs
+}
+
+func (db *Database) GetAllRaidSubscriptions(userID string) []EventSubSubscription {
+	var subs []EventSubSubscription
+	db.Client.Where("target_twitch_id = ? AND type = ?", userID, helix.EventSubTypeChannelRaid).Find(&subs)
 	return subs
 }
 
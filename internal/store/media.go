@@ -20,6 +20,7 @@ type MediaQueue struct {
 	Approved        bool
 	Author          string
 	Approver        string
+	StreamSessionID uint `gorm:"index"`
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
@@ -30,6 +31,7 @@ func (db *Database) AddToQueue(queueItem MediaQueue) error {
 	if err != nil {
 		return err
 	}
+	queueItem.StreamSessionID = db.GetActiveStreamSessionID(queueItem.ChannelTwitchId)
 
 	res := db.Client.Create(&queueItem)
 	if res.Error != nil {
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// MessageTemplate overrides the default text of a bot message in a channel, Disabled silences the message
+type MessageTemplate struct {
+	ChannelTwitchID string `gorm:"primaryKey"`
+	Key             string `gorm:"primaryKey"`
+	Text            string
+	Disabled        bool
+	UpdatedAt       time.Time
+}
+
+func (db *Database) GetMessageTemplates(channelTwitchID string) []MessageTemplate {
+	var templates []MessageTemplate
+
+	db.Client.Where("channel_twitch_id = ?", channelTwitchID).Order("key asc").Find(&templates)
+
+	return templates
+}
+
+func (db *Database) SaveMessageTemplate(ctx context.Context, template MessageTemplate) error {
+	update := db.Client.WithContext(ctx).Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&template)
+
+	return update.Error
+}
+
+func (db *Database) DeleteMessageTemplate(ctx context.Context, channelTwitchID string, key string) error {
+	res := db.Client.WithContext(ctx).Where("channel_twitch_id = ? AND key = ?", channelTwitchID, key).Delete(&MessageTemplate{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errors.New("not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// ModerationConfig enables the chat moderation of a channel, strikes escalate from delete to timeout to ban
+type ModerationConfig struct {
+	ChannelTwitchID string `gorm:"primaryKey"`
+	Enabled         bool
+	FilterLinks     bool
+	// PermitSeconds how long a !permit allows posting links
+	PermitSeconds  int
+	TimeoutSeconds int
+	// BanAfterStrikes bans on that strike, 0 never bans
+	BanAfterStrikes int
+	UpdatedAt       time.Time
+}
+
+type BannedPhrase struct {
+	ID              uint   `gorm:"primaryKey;autoIncrement"`
+	ChannelTwitchID string `gorm:"index"`
+	Phrase          string
+	Regex           bool
+	CreatedAt       time.Time
+}
+
+func (db *Database) GetModerationConfig(channelTwitchID string) (ModerationConfig, error) {
+	var cfg ModerationConfig
+	result := db.Client.Where("channel_twitch_id = ?", channelTwitchID).First(&cfg)
+	if result.RowsAffected == 0 {
+		return ModerationConfig{ChannelTwitchID: channelTwitchID}, errors.New("not found")
+	}
+
+	return cfg, nil
+}
+
+func (db *Database) SaveModerationConfig(ctx context.Context, cfg ModerationConfig) error {
+	update := db.Client.WithContext(ctx).Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&cfg)
+
+	return update.Error
+}
+
+func (db *Database) GetBannedPhrases(channelTwitchID string) []BannedPhrase {
+	var phrases []BannedPhrase
+
+	db.Client.Where("channel_twitch_id = ?", channelTwitchID).Order("id asc").Find(&phrases)
+
+	return phrases
+}
+
+func (db *Database) CreateBannedPhrase(ctx context.Context, phrase BannedPhrase) (BannedPhrase, error) {
+	res := db.Client.WithContext(ctx).Create(&phrase)
+
+	return phrase, res.Error
+}
+
+func (db *Database) DeleteBannedPhrase(ctx context.Context, channelTwitchID string, id uint) error {
+	res := db.Client.WithContext(ctx).Where("channel_twitch_id = ? AND id = ?", channelTwitchID, id).Delete(&BannedPhrase{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errors.New("not found")
+	}
+
+	return nil
+}
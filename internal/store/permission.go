@@ -1,14 +1,25 @@
 package store
 
 import (
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/utils"
 	"gorm.io/gorm/clause"
 )
 
 type Permission struct {
 	ChannelTwitchId string `gorm:"primaryKey"`
 	TwitchID        string `gorm:"primaryKey"`
-	Editor          bool   `gorm:"default:false"`
-	Prediction      bool   `gorm:"default:false"`
+	Capabilities    int64  `gorm:"default:0"`
+}
+
+// Has reports if all bits of capability are granted
+func (p Permission) Has(capability int64) bool {
+	return p.Capabilities != 0 && utils.BitField.HasBits(p.Capabilities, capability)
+}
+
+func (p Permission) HasAny() bool {
+	return p.Capabilities != 0
 }
 
 func (db *Database) GetChannelUserPermissions(userID string, channelID string) Permission {
@@ -46,3 +57,28 @@ func (db *Database) SavePermission(permission Permission) error {
 
 	return update.Error
 }
+
+// migrateLegacyPermissions converts the old editor and prediction columns into capabilities
+func (db *Database) migrateLegacyPermissions() error {
+	migrator := db.Client.Migrator()
+	if !migrator.HasColumn(&Permission{}, "editor") {
+		return nil
+	}
+
+	log.Info("Migrating editor and prediction permissions to capabilities")
+	err := db.Client.Exec("UPDATE permissions SET capabilities = capabilities | ? WHERE editor", dto.CapabilityAll).Error
+	if err != nil {
+		return err
+	}
+	err = db.Client.Exec("UPDATE permissions SET capabilities = capabilities | ? WHERE prediction", dto.CapabilityPredictions).Error
+	if err != nil {
+		return err
+	}
+
+	err = migrator.DropColumn(&Permission{}, "editor")
+	if err != nil {
+		return err
+	}
+
+	return migrator.DropColumn(&Permission{}, "prediction")
+}
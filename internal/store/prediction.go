@@ -6,14 +6,10 @@ import (
 	"time"
 
 	"github.com/gempir/gempbot/internal/dto"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
-const (
-	PREDICTION_RESOLVED = "resolved"
-	PREDICTION_CANCELED = "canceled"
-)
-
 type PredictionLog struct {
 	ID               string `gorm:"primaryKey"`
 	OwnerTwitchID    string `gorm:"index"`
@@ -23,12 +19,14 @@ type PredictionLog struct {
 	StartedAt        time.Time
 	LockedAt         *time.Time
 	EndedAt          *time.Time
+	StreamSessionID  uint                   `gorm:"index"`
 	Outcomes         []PredictionLogOutcome `gorm:"foreignKey:PredictionID;references:ID"`
 }
 
 type PredictionLogOutcome struct {
 	ID            string `gorm:"primaryKey"`
 	PredictionID  string `gorm:"index"`
+	Position      int
 	Title         string
 	Color         string
 	Users         int
@@ -45,14 +43,36 @@ func (o *PredictionLogOutcome) GetColorEmoji() string {
 
 func (db *Database) GetPredictions(ctx context.Context, ownerTwitchID string, page int, pageSize int) []PredictionLog {
 	var predictions []PredictionLog
-	db.Client.WithContext(ctx).Preload("Outcomes").Where("owner_twitch_id = ?", ownerTwitchID).Offset((page * pageSize) - pageSize).Limit(pageSize).Order("started_at desc").Find(&predictions)
+	db.Client.WithContext(ctx).Preload("Outcomes", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position asc")
+	}).Where("owner_twitch_id = ?", ownerTwitchID).Offset((page * pageSize) - pageSize).Limit(pageSize).Order("started_at desc").Find(&predictions)
+
+	return predictions
+}
+
+// GetPredictionsSince returns all predictions with outcomes started after since, oldest first
+func (db *Database) GetPredictionsSince(ctx context.Context, ownerTwitchID string, since time.Time) []PredictionLog {
+	var predictions []PredictionLog
+	db.Client.WithContext(ctx).Preload("Outcomes", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position asc")
+	}).Where("owner_twitch_id = ? AND started_at >= ?", ownerTwitchID, since).Order("started_at asc").Find(&predictions)
 
 	return predictions
 }
 
+func (db *Database) GetPrediction(id string) (PredictionLog, error) {
+	var prediction PredictionLog
+	result := db.Client.Where("id = ?", id).First(&prediction)
+	if result.RowsAffected == 0 {
+		return prediction, errors.New("not found")
+	}
+
+	return prediction, nil
+}
+
 func (db *Database) GetActivePrediction(ownerTwitchID string) (PredictionLog, error) {
 	var reward PredictionLog
-	result := db.Client.Where("owner_twitch_id = ? AND winning_outcome_id = ''", ownerTwitchID).Order("started_at desc").First(&reward)
+	result := db.Client.Where("owner_twitch_id = ? AND status IN ?", ownerTwitchID, []string{dto.PredictionStatusActive, dto.PredictionStatusLocked}).Order("started_at desc").First(&reward)
 	if result.RowsAffected == 0 {
 		return reward, errors.New("not found")
 	}
@@ -62,17 +82,34 @@ func (db *Database) GetActivePrediction(ownerTwitchID string) (PredictionLog, er
 
 func (db *Database) GetOutcomes(predictionID string) []PredictionLogOutcome {
 	var outcomes []PredictionLogOutcome
-	db.Client.Where("prediction_id = ?", predictionID).Find(&outcomes)
+	db.Client.Where("prediction_id = ?", predictionID).Order("position asc").Find(&outcomes)
 
 	return outcomes
 }
 
+// SavePrediction upserts the prediction and all of its outcomes, it stays linked to the stream it started in
 func (db *Database) SavePrediction(log PredictionLog) error {
-	update := db.Client.Clauses(clause.OnConflict{
+	if existing, err := db.GetPrediction(log.ID); err == nil {
+		log.StreamSessionID = existing.StreamSessionID
+	} else {
+		log.StreamSessionID = db.GetActiveStreamSessionID(log.OwnerTwitchID)
+	}
+
+	update := db.Client.Omit(clause.Associations).Clauses(clause.OnConflict{
 		UpdateAll: true,
 	}).Create(&log)
+	if update.Error != nil {
+		return update.Error
+	}
 
-	return update.Error
+	for _, outcome := range log.Outcomes {
+		err := db.SaveOutcome(outcome)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (db *Database) SaveOutcome(log PredictionLogOutcome) error {
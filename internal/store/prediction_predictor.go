@@ -0,0 +1,55 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// PredictionLogPredictor is one of the top predictors twitch reports per outcome
+type PredictionLogPredictor struct {
+	PredictionID      string `gorm:"primaryKey"`
+	UserID            string `gorm:"primaryKey;index"`
+	UserLogin         string
+	UserName          string
+	OutcomeID         string
+	ChannelPointsUsed int
+	ChannelPointsWon  int
+}
+
+type TopPredictor struct {
+	UserID            string
+	UserLogin         string
+	Predictions       int
+	ChannelPointsUsed int
+	ChannelPointsWon  int
+}
+
+func (db *Database) SavePredictors(predictors []PredictionLogPredictor) error {
+	if len(predictors) == 0 {
+		return nil
+	}
+
+	update := db.Client.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&predictors)
+
+	return update.Error
+}
+
+// GetTopPredictors sums up the top predictor entries of a channel, ordered by points won
+func (db *Database) GetTopPredictors(ctx context.Context, ownerTwitchID string, since time.Time, limit int) []TopPredictor {
+	var predictors []TopPredictor
+
+	db.Client.WithContext(ctx).Model(&PredictionLogPredictor{}).
+		Select("prediction_log_predictors.user_id, MAX(prediction_log_predictors.user_login) AS user_login, COUNT(*) AS predictions, SUM(prediction_log_predictors.channel_points_used) AS channel_points_used, SUM(prediction_log_predictors.channel_points_won) AS channel_points_won").
+		Joins("JOIN prediction_logs ON prediction_logs.id = prediction_log_predictors.prediction_id").
+		Where("prediction_logs.owner_twitch_id = ? AND prediction_logs.started_at >= ?", ownerTwitchID, since).
+		Group("prediction_log_predictors.user_id").
+		Order("channel_points_won desc").
+		Limit(limit).
+		Scan(&predictors)
+
+	return predictors
+}
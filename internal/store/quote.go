@@ -0,0 +1,94 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Quote is numbered per channel, numbers of deleted quotes are not reused
+type Quote struct {
+	ChannelTwitchID string `gorm:"primaryKey"`
+	Number          int    `gorm:"primaryKey;autoIncrement:false"`
+	Text            string
+	AuthorTwitchID  string
+	AuthorName      string
+	Category        string
+	CreatedAt       time.Time
+}
+
+// QuoteCounter keeps the last used quote number of a channel
+type QuoteCounter struct {
+	ChannelTwitchID string `gorm:"primaryKey"`
+	LastNumber      int
+}
+
+// CreateQuote assigns the next number of the channel and stores the quote
+func (db *Database) CreateQuote(ctx context.Context, quote Quote) (Quote, error) {
+	err := db.Client.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		counter := QuoteCounter{ChannelTwitchID: quote.ChannelTwitchID}
+		res := tx.Exec("INSERT INTO quote_counters (channel_twitch_id, last_number) VALUES (?, 1) ON CONFLICT (channel_twitch_id) DO UPDATE SET last_number = quote_counters.last_number + 1", quote.ChannelTwitchID)
+		if res.Error != nil {
+			return res.Error
+		}
+		res = tx.Where("channel_twitch_id = ?", quote.ChannelTwitchID).First(&counter)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		quote.Number = counter.LastNumber
+		return tx.Create(&quote).Error
+	})
+
+	return quote, err
+}
+
+func (db *Database) GetQuote(channelTwitchID string, number int) (Quote, error) {
+	var quote Quote
+	result := db.Client.Where("channel_twitch_id = ? AND number = ?", channelTwitchID, number).First(&quote)
+	if result.RowsAffected == 0 {
+		return quote, errors.New("not found")
+	}
+
+	return quote, nil
+}
+
+func (db *Database) GetRandomQuote(channelTwitchID string) (Quote, error) {
+	var quote Quote
+	result := db.Client.Where("channel_twitch_id = ?", channelTwitchID).Order("RANDOM()").Limit(1).Find(&quote)
+	if result.RowsAffected == 0 {
+		return quote, errors.New("not found")
+	}
+
+	return quote, nil
+}
+
+// GetQuotes pages through the quotes of a channel, search matches the text, author and category
+func (db *Database) GetQuotes(ctx context.Context, channelTwitchID string, search string, page int, pageSize int) []Quote {
+	var quotes []Quote
+
+	query := db.Client.WithContext(ctx).Where("channel_twitch_id = ?", channelTwitchID)
+	if search = strings.TrimSpace(search); search != "" {
+		like := "%" + strings.NewReplacer("%", "\\%", "_", "\\_").Replace(search) + "%"
+		query = query.Where("text ILIKE ? OR author_name ILIKE ? OR category ILIKE ?", like, like, like)
+	}
+
+	query.Offset((page * pageSize) - pageSize).Limit(pageSize).Order("number desc").Find(&quotes)
+
+	return quotes
+}
+
+func (db *Database) DeleteQuote(ctx context.Context, channelTwitchID string, number int) error {
+	res := db.Client.WithContext(ctx).Where("channel_twitch_id = ? AND number = ?", channelTwitchID, number).Delete(&Quote{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errors.New("not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StreamSession struct {
+	ID              uint   `gorm:"primaryKey;autoIncrement"`
+	ChannelTwitchID string `gorm:"index"`
+	StreamID        string `gorm:"uniqueIndex"`
+	Title           string
+	CategoryID      string
+	CategoryName    string
+	StartedAt       time.Time
+	EndedAt         *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// StartStreamSession creates the session, a repeated stream.online for the same stream is ignored
+func (db *Database) StartStreamSession(ctx context.Context, session StreamSession) error {
+	res := db.Client.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "stream_id"}},
+		DoNothing: true,
+	}).Create(&session)
+
+	return res.Error
+}
+
+// EndStreamSession ends every open session of the channel
+func (db *Database) EndStreamSession(ctx context.Context, channelTwitchID string, endedAt time.Time) error {
+	res := db.Client.WithContext(ctx).Model(&StreamSession{}).
+		Where("channel_twitch_id = ? AND ended_at IS NULL", channelTwitchID).
+		Update("ended_at", endedAt)
+
+	return res.Error
+}
+
+// GetActiveStreamSessionID returns 0 when the channel is offline
+func (db *Database) GetActiveStreamSessionID(channelTwitchID string) uint {
+	var session StreamSession
+	db.Client.Where("channel_twitch_id = ? AND ended_at IS NULL", channelTwitchID).Order("started_at desc").Limit(1).Find(&session)
+
+	return session.ID
+}
+
+func (db *Database) GetStreamSessions(ctx context.Context, channelTwitchID string, page int, pageSize int) []StreamSession {
+	var sessions []StreamSession
+	db.Client.WithContext(ctx).Where("channel_twitch_id = ?", channelTwitchID).Offset((page * pageSize) - pageSize).Limit(pageSize).Order("started_at desc").Find(&sessions)
+
+	return sessions
+}
+
+func (db *Database) GetStreamSession(ctx context.Context, channelTwitchID string, id uint) (StreamSession, error) {
+	var session StreamSession
+	result := db.Client.WithContext(ctx).Where("channel_twitch_id = ? AND id = ?", channelTwitchID, id).First(&session)
+	if result.RowsAffected == 0 {
+		return session, errors.New("not found")
+	}
+
+	return session, nil
+}
+
+func (db *Database) GetStreamSessionEmoteChanges(ctx context.Context, sessionID uint) []EmoteAdd {
+	var changes []EmoteAdd
+	db.Client.WithContext(ctx).Where("stream_session_id = ?", sessionID).Order("created_at asc").Find(&changes)
+
+	return changes
+}
+
+func (db *Database) GetStreamSessionPredictions(ctx context.Context, sessionID uint) []PredictionLog {
+	var predictions []PredictionLog
+	db.Client.WithContext(ctx).Preload("Outcomes", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position asc")
+	}).Where("stream_session_id = ?", sessionID).Order("started_at asc").Find(&predictions)
+
+	return predictions
+}
+
+func (db *Database) GetStreamSessionMedia(ctx context.Context, sessionID uint) []MediaQueue {
+	var queue []MediaQueue
+	db.Client.WithContext(ctx).Where("stream_session_id = ?", sessionID).Order("created_at asc").Find(&queue)
+
+	return queue
+}
@@ -2,11 +2,20 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/gempir/gempbot/internal/dto"
 )
 
+// MockStore returns fixed data, set EmoteAdded, EmoteUsages, EmoteProtections, ChannelPointRewards or Permissions to return other data.
+// Emote changes are collected in CreatedEmoteAdds.
 type MockStore struct {
+	EmoteAdded          []EmoteAdd
+	EmoteUsages         []EmoteUsage
+	EmoteProtections    []EmoteProtection
+	ChannelPointRewards []ChannelPointReward
+	Permissions         []Permission
+	CreatedEmoteAdds    []EmoteAdd
 }
 
 func NewMockStore() *MockStore {
@@ -17,14 +26,50 @@ func (s *MockStore) IsEmoteBlocked(channelUserID string, emoteID string, rewardT
 	return false
 }
 
+func (s *MockStore) GetEmoteProtections(channelTwitchID string) []EmoteProtection {
+	return s.EmoteProtections
+}
+
+func (s *MockStore) GetChannelUserPermissions(userID string, channelID string) Permission {
+	for _, perm := range s.Permissions {
+		if perm.TwitchID == userID && perm.ChannelTwitchId == channelID {
+			return perm
+		}
+	}
+
+	return Permission{}
+}
+
 func (s *MockStore) GetEmoteAdded(channelUserID string, rewardType dto.RewardType, slots int) []EmoteAdd {
+	if s.EmoteAdded != nil {
+		if len(s.EmoteAdded) > slots {
+			return s.EmoteAdded[:slots]
+		}
+		return s.EmoteAdded
+	}
+
 	return []EmoteAdd{
 		{ID: 1, ChannelTwitchID: "channelid", Type: dto.REWARD_SEVENTV, EmoteID: "emoteid"},
 	}
 }
 
 func (s *MockStore) CreateEmoteAdd(channelUserId string, rewardType dto.RewardType, emoteID string, changeType dto.EmoteChangeType) {
-	// do nothing
+	s.CreatedEmoteAdds = append(s.CreatedEmoteAdds, EmoteAdd{ChannelTwitchID: channelUserId, Type: rewardType, EmoteID: emoteID, ChangeType: changeType})
+}
+
+func (s *MockStore) GetExpiredEmoteAdds(ctx context.Context, channelTwitchID string, addType dto.RewardType, from time.Time, to time.Time) []EmoteAdd {
+	emoteAdds := []EmoteAdd{}
+	for _, emoteAdd := range s.EmoteAdded {
+		if emoteAdd.Type == addType && emoteAdd.ChangeType == dto.EMOTE_ADD_ADD && !emoteAdd.CreatedAt.Before(from) && emoteAdd.CreatedAt.Before(to) {
+			emoteAdds = append(emoteAdds, emoteAdd)
+		}
+	}
+
+	return emoteAdds
+}
+
+func (s *MockStore) GetLastEmoteAdd(channelTwitchID string, addType dto.RewardType, emoteID string) (EmoteAdd, error) {
+	return EmoteAdd{ChannelTwitchID: channelTwitchID, Type: addType, EmoteID: emoteID, ChangeType: dto.EMOTE_ADD_ADD}, nil
 }
 
 func (s *MockStore) GetUserAccessToken(userID string) (UserAccessToken, error) {
@@ -93,9 +138,19 @@ func (s *MockStore) DeleteChannelPointRewardById(userID string, rewardID string)
 }
 
 func (s *MockStore) GetChannelPointReward(userID string, rewardType dto.RewardType) (ChannelPointReward, error) {
+	for _, reward := range s.ChannelPointRewards {
+		if reward.OwnerTwitchID == userID && reward.Type == rewardType {
+			return reward, nil
+		}
+	}
+
 	return ChannelPointReward{}, nil
 }
 
+func (s *MockStore) GetEmoteChannelPointRewards(ctx context.Context) []ChannelPointReward {
+	return s.ChannelPointRewards
+}
+
 func (s *MockStore) CreateNominationVote(ctx context.Context, vote NominationVote) error {
 	return nil
 }
@@ -139,3 +194,19 @@ func (s *MockStore) CountNominationDownvotes(ctx context.Context, channelTwitchI
 func (s *MockStore) CountNominationVotes(ctx context.Context, channelTwitchID string, voteBy string) (int, error) {
 	return 0, nil
 }
+
+func (s *MockStore) GetMessageTemplates(channelTwitchID string) []MessageTemplate {
+	return []MessageTemplate{}
+}
+
+func (s *MockStore) GetAllDryRunBotConfigs() []BotConfig {
+	return []BotConfig{}
+}
+
+func (s *MockStore) CreateDryRunAction(ctx context.Context, action DryRunAction) error {
+	return nil
+}
+
+func (s *MockStore) GetEmoteUsages(ctx context.Context, channelTwitchID string, from time.Time) []EmoteUsage {
+	return s.EmoteUsages
+}
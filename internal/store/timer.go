@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type Timer struct {
+	ID              uint   `gorm:"primaryKey;autoIncrement"`
+	ChannelTwitchID string `gorm:"index"`
+	Message         string
+	IntervalMinutes int
+	MinChatLines    int
+	Enabled         bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (db *Database) GetTimers(channelTwitchID string) []Timer {
+	var timers []Timer
+
+	db.Client.Where("channel_twitch_id = ?", channelTwitchID).Order("id asc").Find(&timers)
+
+	return timers
+}
+
+func (db *Database) GetAllEnabledTimers() []Timer {
+	var timers []Timer
+
+	db.Client.Where("enabled = ?", true).Find(&timers)
+
+	return timers
+}
+
+// SaveTimer creates the timer or updates it when the ID is already known in the channel
+func (db *Database) SaveTimer(ctx context.Context, timer Timer) (Timer, error) {
+	if timer.ID == 0 {
+		res := db.Client.WithContext(ctx).Create(&timer)
+		return timer, res.Error
+	}
+
+	res := db.Client.WithContext(ctx).Model(&Timer{}).
+		Where("id = ? AND channel_twitch_id = ?", timer.ID, timer.ChannelTwitchID).
+		Select("message", "interval_minutes", "min_chat_lines", "enabled", "updated_at").
+		Updates(&timer)
+	if res.Error != nil {
+		return timer, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return timer, errors.New("not found")
+	}
+
+	return timer, nil
+}
+
+func (db *Database) DeleteTimer(ctx context.Context, channelTwitchID string, id uint) error {
+	res := db.Client.WithContext(ctx).Where("channel_twitch_id = ? AND id = ?", channelTwitchID, id).Delete(&Timer{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errors.New("not found")
+	}
+
+	return nil
+}
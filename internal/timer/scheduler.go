@@ -0,0 +1,202 @@
+package timer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gempir/gempbot/internal/helixclient"
+	"github.com/gempir/gempbot/internal/log"
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/gempir/go-twitch-irc/v4"
+	"github.com/puzpuzpuz/xsync"
+)
+
+const tickInterval = 30 * time.Second
+
+type storage interface {
+	GetTimers(channelTwitchID string) []store.Timer
+	GetAllEnabledTimers() []store.Timer
+}
+
+// Scheduler posts the timers of a channel while it is live and chat is active
+type Scheduler struct {
+	db          storage
+	helixClient helixclient.Client
+	say         func(channel, message string)
+	channels    *xsync.MapOf[string, *channel]
+	now         func() time.Time
+}
+
+type channel struct {
+	mu     sync.Mutex
+	login  string
+	live   bool
+	lines  int
+	timers []*timer
+}
+
+type timer struct {
+	store.Timer
+	lastPost      time.Time
+	linesLastPost int
+}
+
+func NewScheduler(db storage, helixClient helixclient.Client, say func(channel, message string)) *Scheduler {
+	return &Scheduler{
+		db:          db,
+		helixClient: helixClient,
+		say:         say,
+		channels:    xsync.NewMapOf[*channel](),
+		now:         time.Now,
+	}
+}
+
+// Start loads all enabled timers, checks which channels are already live and begins ticking
+func (s *Scheduler) Start() {
+	channelIDs := []string{}
+	for _, t := range s.db.GetAllEnabledTimers() {
+		if _, ok := s.channels.Load(t.ChannelTwitchID); !ok {
+			channelIDs = append(channelIDs, t.ChannelTwitchID)
+			s.loadTimers(t.ChannelTwitchID)
+		}
+	}
+
+	s.loadLive(channelIDs)
+
+	for range time.NewTicker(tickInterval).C {
+		s.tick()
+	}
+}
+
+func (s *Scheduler) loadLive(channelIDs []string) {
+	live, err := s.helixClient.GetLiveUserIDs(channelIDs)
+	if err != nil {
+		log.Error(err)
+	}
+	for channelID := range live {
+		s.SetLive(channelID, "", true)
+	}
+}
+
+// ReloadChannel refreshes the timers of a channel, keeping the progress of timers that still exist.
+// Channels seen for the first time also fetch their live status.
+func (s *Scheduler) ReloadChannel(channelID string) {
+	if s.loadTimers(channelID) {
+		s.loadLive([]string{channelID})
+	}
+}
+
+// loadTimers reports if the channel was not known yet
+func (s *Scheduler) loadTimers(channelID string) bool {
+	ch, known := s.channels.LoadOrStore(channelID, &channel{})
+
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	previous := map[uint]*timer{}
+	for _, t := range ch.timers {
+		previous[t.ID] = t
+	}
+
+	ch.timers = []*timer{}
+	for _, t := range s.db.GetTimers(channelID) {
+		if !t.Enabled {
+			continue
+		}
+
+		entry := &timer{Timer: t, lastPost: s.now(), linesLastPost: ch.lines}
+		if prev, ok := previous[t.ID]; ok {
+			entry.lastPost = prev.lastPost
+			entry.linesLastPost = prev.linesLastPost
+		}
+		ch.timers = append(ch.timers, entry)
+	}
+
+	return !known
+}
+
+// SetLive updates the stream status, going live restarts every timer of the channel
+func (s *Scheduler) SetLive(channelID, channelLogin string, live bool) {
+	ch, _ := s.channels.LoadOrStore(channelID, &channel{})
+
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	if channelLogin != "" {
+		ch.login = channelLogin
+	}
+	if live && !ch.live {
+		for _, t := range ch.timers {
+			t.lastPost = s.now()
+			t.linesLastPost = ch.lines
+		}
+	}
+	ch.live = live
+}
+
+// HandlePrivateMessage counts the chat lines timers wait for
+func (s *Scheduler) HandlePrivateMessage(msg twitch.PrivateMessage) {
+	ch, ok := s.channels.Load(msg.RoomID)
+	if !ok {
+		return
+	}
+
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	ch.login = msg.Channel
+	if ch.live {
+		ch.lines++
+	}
+}
+
+func (s *Scheduler) tick() {
+	s.channels.Range(func(channelID string, ch *channel) bool {
+		login, messages := s.due(ch)
+		if len(messages) == 0 {
+			return true
+		}
+
+		if login == "" {
+			userData, err := s.helixClient.GetUserByUserID(channelID)
+			if err != nil {
+				log.Error(err)
+				return true
+			}
+			login = userData.Login
+		}
+
+		for _, message := range messages {
+			s.say(login, message)
+		}
+
+		return true
+	})
+}
+
+// due returns the channel login and the messages of timers that should be posted now, it marks them as posted
+func (s *Scheduler) due(ch *channel) (string, []string) {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	messages := []string{}
+	if !ch.live {
+		return ch.login, messages
+	}
+
+	now := s.now()
+	for _, t := range ch.timers {
+		if now.Sub(t.lastPost) < time.Duration(t.IntervalMinutes)*time.Minute {
+			continue
+		}
+		if ch.lines-t.linesLastPost < t.MinChatLines {
+			continue
+		}
+
+		t.lastPost = now
+		t.linesLastPost = ch.lines
+		messages = append(messages, t.Message)
+	}
+
+	return ch.login, messages
+}
@@ -0,0 +1,98 @@
+package timer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gempir/gempbot/internal/helixclient"
+	"github.com/gempir/gempbot/internal/store"
+	"github.com/gempir/go-twitch-irc/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockStorage struct {
+	timers []store.Timer
+}
+
+func (m *mockStorage) GetTimers(channelTwitchID string) []store.Timer {
+	return m.timers
+}
+
+func (m *mockStorage) GetAllEnabledTimers() []store.Timer {
+	return m.timers
+}
+
+func newTestScheduler(now *time.Time, timers ...store.Timer) (*Scheduler, *[]string) {
+	said := []string{}
+	s := NewScheduler(&mockStorage{timers: timers}, helixclient.NewMockClient(), func(channel, message string) {
+		said = append(said, channel+": "+message)
+	})
+	s.now = func() time.Time { return *now }
+
+	return s, &said
+}
+
+func chat(s *Scheduler, lines int) {
+	for i := 0; i < lines; i++ {
+		s.HandlePrivateMessage(twitch.PrivateMessage{RoomID: "123", Channel: "gempir"})
+	}
+}
+
+func TestTimerOnlyPostsWhileLive(t *testing.T) {
+	now := time.Now()
+	s, said := newTestScheduler(&now, store.Timer{ID: 1, ChannelTwitchID: "123", Message: "follow the socials", IntervalMinutes: 20, Enabled: true})
+	s.ReloadChannel("123")
+
+	now = now.Add(time.Hour)
+	s.tick()
+	assert.Empty(t, *said, "channel is offline")
+
+	s.SetLive("123", "gempir", true)
+	s.tick()
+	assert.Empty(t, *said, "going live restarts the interval")
+
+	now = now.Add(20 * time.Minute)
+	s.tick()
+	assert.Equal(t, []string{"gempir: follow the socials"}, *said)
+
+	s.SetLive("123", "gempir", false)
+	now = now.Add(20 * time.Minute)
+	s.tick()
+	assert.Len(t, *said, 1)
+}
+
+func TestTimerWaitsForChatLines(t *testing.T) {
+	now := time.Now()
+	s, said := newTestScheduler(&now, store.Timer{ID: 1, ChannelTwitchID: "123", Message: "follow the socials", IntervalMinutes: 10, MinChatLines: 5, Enabled: true})
+	s.ReloadChannel("123")
+	s.SetLive("123", "gempir", true)
+
+	chat(s, 4)
+	now = now.Add(10 * time.Minute)
+	s.tick()
+	assert.Empty(t, *said, "not enough chat lines yet")
+
+	chat(s, 1)
+	s.tick()
+	assert.Len(t, *said, 1)
+
+	chat(s, 5)
+	now = now.Add(5 * time.Minute)
+	s.tick()
+	assert.Len(t, *said, 1, "lines alone do not skip the interval")
+
+	now = now.Add(5 * time.Minute)
+	s.tick()
+	assert.Len(t, *said, 2)
+}
+
+func TestDisabledTimersAreIgnored(t *testing.T) {
+	now := time.Now()
+	s, said := newTestScheduler(&now, store.Timer{ID: 1, ChannelTwitchID: "123", Message: "follow the socials", IntervalMinutes: 5, Enabled: false})
+	s.ReloadChannel("123")
+	s.SetLive("123", "gempir", true)
+
+	now = now.Add(time.Hour)
+	s.tick()
+	assert.Empty(t, *said)
+}
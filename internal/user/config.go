@@ -8,10 +8,12 @@ import (
 	"github.com/gempir/gempbot/internal/api"
 	"github.com/gempir/gempbot/internal/chat"
 	"github.com/gempir/gempbot/internal/config"
+	"github.com/gempir/gempbot/internal/dto"
 	"github.com/gempir/gempbot/internal/helixclient"
 	"github.com/gempir/gempbot/internal/log"
 	"github.com/gempir/gempbot/internal/slice"
 	"github.com/gempir/gempbot/internal/store"
+	"github.com/gempir/gempbot/internal/utils"
 )
 
 type UserAdmin struct {
@@ -28,16 +30,22 @@ func NewUserAdmin(cfg *config.Config, db *store.Database, helixClient helixclien
 type UserConfig struct {
 	Permissions map[string]Permission
 	Protected   Protected
+	// capabilitiesFor holds the capabilities the current user has in the channels they are editor for
+	capabilitiesFor map[string]int64
 }
 
-func (u *UserConfig) isEditor(userID string) bool {
+func (u *UserConfig) hasCapability(userID string, capability int64) bool {
 	val, ok := u.Permissions[userID]
 
-	return ok && val.Editor
+	return ok && val.Capabilities != 0 && utils.BitField.HasBits(val.Capabilities, capability)
 }
 
-func (u *UserConfig) isEditorFor(userID string) bool {
-	return slice.Contains(u.Protected.EditorFor, userID)
+func (u *UserConfig) HasCapabilityFor(channelID string, capability int64) bool {
+	if !slice.Contains(u.Protected.EditorFor, channelID) {
+		return false
+	}
+
+	return utils.BitField.HasBits(u.capabilitiesFor[channelID], capability)
 }
 
 type Protected struct {
@@ -46,8 +54,7 @@ type Protected struct {
 }
 
 type Permission struct {
-	Editor     bool
-	Prediction bool
+	Capabilities int64
 }
 
 func createDefaultUserConfig() UserConfig {
@@ -57,6 +64,7 @@ func createDefaultUserConfig() UserConfig {
 			EditorFor:     []string{},
 			CurrentUserID: "",
 		},
+		capabilitiesFor: map[string]int64{},
 	}
 }
 
@@ -67,12 +75,13 @@ func (u *UserAdmin) GetUserConfig(userID string) UserConfig {
 
 	perms := u.db.GetChannelPermissions(userID)
 	for _, perm := range perms {
-		uCfg.Permissions[perm.TwitchID] = Permission{perm.Editor, perm.Prediction}
+		uCfg.Permissions[perm.TwitchID] = Permission{perm.Capabilities}
 	}
 
 	for _, perm := range u.db.GetUserPermissions(userID) {
-		if perm.Editor {
+		if perm.HasAny() {
 			uCfg.Protected.EditorFor = append(uCfg.Protected.EditorFor, perm.ChannelTwitchId)
+			uCfg.capabilitiesFor[perm.ChannelTwitchId] = perm.Capabilities
 		}
 	}
 
@@ -140,7 +149,8 @@ func (u *UserAdmin) ConvertUserConfig(uCfg UserConfig, toNames bool) (UserConfig
 	return uCfg, nil
 }
 
-func (u *UserAdmin) CheckEditor(r *http.Request, userConfig UserConfig) (string, api.Error) {
+// CheckPermission resolves the managed channel and checks the user has the capability there, pass 0 to only require any capability
+func (u *UserAdmin) CheckPermission(r *http.Request, userConfig UserConfig, capability int64) (string, api.Error) {
 	managing := r.URL.Query().Get("managing")
 
 	userData, err := u.helixClient.GetUsersByUsernames([]string{managing})
@@ -148,31 +158,32 @@ func (u *UserAdmin) CheckEditor(r *http.Request, userConfig UserConfig) (string,
 		return "", api.NewApiError(http.StatusForbidden, fmt.Errorf("could not find managing"))
 	}
 
-	if !userConfig.isEditorFor(userData[managing].ID) {
-		return "", api.NewApiError(http.StatusForbidden, fmt.Errorf("user is not editor"))
+	if !userConfig.HasCapabilityFor(userData[managing].ID, capability) {
+		return "", api.NewApiError(http.StatusForbidden, fmt.Errorf("user is missing permission"))
 	}
 
 	return userData[managing].ID, nil
 }
 
-func (u *UserAdmin) ProcessConfig(ctx context.Context, userID string, login string, newConfig UserConfig, managing string) api.Error {
+// ProcessConfig saves the config and returns the channel it belongs to
+func (u *UserAdmin) ProcessConfig(ctx context.Context, userID string, login string, newConfig UserConfig, managing string) (string, api.Error) {
 	isManaging := managing != ""
 	ownerUserID := userID
 	newUserIDConfig, err := u.ConvertUserConfig(newConfig, false)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if isManaging {
 		uData, err := u.helixClient.GetUserByUsername(managing)
 		if err != nil {
-			return api.NewApiError(http.StatusBadRequest, fmt.Errorf("could not find managing"))
+			return "", api.NewApiError(http.StatusBadRequest, fmt.Errorf("could not find managing"))
 		}
 		ownerUserID = uData.ID
 		oldConfig := u.GetUserConfig(uData.ID)
 
-		if !oldConfig.isEditor(userID) {
-			return api.NewApiError(http.StatusForbidden, fmt.Errorf("user is not editor"))
+		if !oldConfig.hasCapability(userID, dto.CapabilityPermissions) {
+			return "", api.NewApiError(http.StatusForbidden, fmt.Errorf("user is missing permission"))
 		}
 	}
 
@@ -192,7 +203,7 @@ func (u *UserAdmin) ProcessConfig(ctx context.Context, userID string, login stri
 	}
 
 	for permissionUserID, perm := range newUserIDConfig.Permissions {
-		newPerms := store.Permission{ChannelTwitchId: ownerUserID, TwitchID: permissionUserID, Prediction: perm.Prediction, Editor: perm.Editor}
+		newPerms := store.Permission{ChannelTwitchId: ownerUserID, TwitchID: permissionUserID, Capabilities: perm.Capabilities & dto.CapabilityAll}
 
 		err := u.db.SavePermission(newPerms)
 		if err != nil {
@@ -200,5 +211,5 @@ func (u *UserAdmin) ProcessConfig(ctx context.Context, userID string, login stri
 		}
 	}
 
-	return nil
+	return ownerUserID, nil
 }
@@ -0,0 +1,22 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/gempir/gempbot/internal/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasCapabilityFor(t *testing.T) {
+	uCfg := createDefaultUserConfig()
+	uCfg.Protected.EditorFor = []string{"1", "2"}
+	uCfg.capabilitiesFor["1"] = dto.CapabilityPredictions | dto.CapabilityMedia
+	uCfg.capabilitiesFor["2"] = dto.CapabilityAll
+
+	assert.True(t, uCfg.HasCapabilityFor("1", dto.CapabilityMedia))
+	assert.True(t, uCfg.HasCapabilityFor("1", 0))
+	assert.False(t, uCfg.HasCapabilityFor("1", dto.CapabilityBotConfig))
+	assert.False(t, uCfg.HasCapabilityFor("1", dto.CapabilityPredictions|dto.CapabilityBlocks))
+	assert.True(t, uCfg.HasCapabilityFor("2", dto.CapabilityPermissions))
+	assert.False(t, uCfg.HasCapabilityFor("3", 0))
+}
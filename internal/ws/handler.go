@@ -88,10 +88,11 @@ func startWriter(conn *websocket.Conn, writeQueue chan []byte) {
 }
 
 type PlayerStateMessage struct {
-	Action string            `json:"action"`
-	Time   float32           `json:"time"`
-	Url    string            `json:"url"`
-	State  media.PlayerState `json:"state"`
+	Action  string            `json:"action"`
+	Channel string            `json:"channel"`
+	Time    float32           `json:"time"`
+	Url     string            `json:"url"`
+	State   media.PlayerState `json:"state"`
 }
 
 type Join struct {
@@ -131,7 +132,7 @@ func (h *WsHandler) handleMessage(connectionId string, userId string, byteMessag
 			log.Errorf("Failed to unmarshal PlayerState message: %s", err)
 			return
 		}
-		h.mediaManager.HandlePlayerState(connectionId, userId, msg.State, msg.Url, msg.Time)
+		h.mediaManager.HandlePlayerState(connectionId, userId, msg.Channel, msg.State, msg.Url, msg.Time)
 	case actionJoin:
 		var msg Join
 		err := json.Unmarshal(byteMessage, &msg)
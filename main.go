@@ -7,15 +7,21 @@ import (
 	"github.com/gempir/gempbot/internal/auth"
 	"github.com/gempir/gempbot/internal/bot"
 	"github.com/gempir/gempbot/internal/channelpoint"
+	"github.com/gempir/gempbot/internal/chatlog"
 	"github.com/gempir/gempbot/internal/config"
+	"github.com/gempir/gempbot/internal/dryrun"
 	"github.com/gempir/gempbot/internal/emotechief"
 	"github.com/gempir/gempbot/internal/emoteservice"
+	"github.com/gempir/gempbot/internal/emoteusage"
 	"github.com/gempir/gempbot/internal/eventsubmanager"
 	"github.com/gempir/gempbot/internal/helixclient"
 	"github.com/gempir/gempbot/internal/log"
 	"github.com/gempir/gempbot/internal/media"
+	"github.com/gempir/gempbot/internal/moderation"
 	"github.com/gempir/gempbot/internal/server"
+	"github.com/gempir/gempbot/internal/shoutout"
 	"github.com/gempir/gempbot/internal/store"
+	"github.com/gempir/gempbot/internal/timer"
 	"github.com/gempir/gempbot/internal/user"
 	"github.com/gempir/gempbot/internal/ws"
 	"github.com/rs/cors"
@@ -35,22 +41,51 @@ func main() {
 
 	helixClient := helixclient.NewClient(cfg, db)
 	// go helixClient.StartRefreshTokenRoutine()
+	dryRun := dryrun.NewRecorder(cfg, db, helixClient)
+	helixClient.SetDryRun(dryRun)
 
 	userAdmin := user.NewUserAdmin(cfg, db, helixClient, nil)
 	authClient := auth.NewAuth(cfg, db, helixClient)
 
 	bot := bot.NewBot(cfg, db, helixClient)
+	bot.ChatClient.SetDryRun(dryRun)
 	go bot.Connect()
 
 	seventvClient := emoteservice.NewSevenTvClient(db)
+	seventvClient.SetDryRun(dryRun)
 
-	emoteChief := emotechief.NewEmoteChief(cfg, db, helixClient, bot.ChatClient, seventvClient)
+	emoteChief := emotechief.NewEmoteChief(cfg, db, helixClient, bot.Messenger, seventvClient)
+	emoteChief.SetDryRun(dryRun)
+	emoteChief.RegisterCommands(bot)
+	go emoteChief.StartExpiry()
 	channelPointManager := channelpoint.NewChannelPointManager(cfg, helixClient, db)
-	mediaManager := media.NewMediaManager(db, helixClient, bot)
+	mediaManager := media.NewMediaManager(db, helixClient, bot, bot.Messenger)
 	wsHandler := ws.NewWsHandler(authClient, mediaManager)
-	eventsubManager := eventsubmanager.NewEventsubManager(cfg, helixClient, db, emoteChief, bot.ChatClient)
+	eventsubManager := eventsubmanager.NewEventsubManager(cfg, helixClient, db, emoteChief, bot.Messenger)
 
-	apiHandlers := server.NewApi(cfg, db, helixClient, userAdmin, authClient, bot, emoteChief, eventsubManager, channelPointManager, seventvClient, wsHandler)
+	timerScheduler := timer.NewScheduler(db, helixClient, bot.ChatClient.Say)
+	bot.OnPrivateMessage(timerScheduler.HandlePrivateMessage)
+	eventsubManager.RegisterStreamStatusCallback(timerScheduler.SetLive)
+	go eventsubManager.SubscribeAllStreamStatus()
+	go eventsubManager.SubscribeAllPredictionsProgress()
+	go timerScheduler.Start()
+
+	emoteUsageCounter := emoteusage.NewCounter(db, seventvClient, emotechief.GetBttvEmotes)
+	bot.OnPrivateMessage(emoteUsageCounter.HandlePrivateMessage)
+	go emoteUsageCounter.Start()
+
+	chatLogger := chatlog.NewLogger(cfg, db)
+	bot.OnPrivateMessage(chatLogger.HandlePrivateMessage)
+	go chatLogger.Start()
+
+	moderator := moderation.NewModerator(db, helixClient, bot, bot.Messenger)
+	bot.OnPrivateMessage(moderator.HandlePrivateMessage)
+	go moderator.Start()
+
+	shouter := shoutout.NewShouter(db, helixClient, bot, bot.Messenger)
+	eventsubManager.RegisterRaidCallback(shouter.HandleRaid)
+
+	apiHandlers := server.NewApi(cfg, db, helixClient, userAdmin, authClient, bot, emoteChief, eventsubManager, channelPointManager, seventvClient, wsHandler, timerScheduler, moderator, dryRun, chatLogger)
 
 	mux := http.NewServeMux()
 
@@ -63,12 +98,27 @@ func main() {
 		http.Error(w, "404 page not found", http.StatusNotFound)
 	})
 	mux.HandleFunc("/api/blocks", apiHandlers.BlocksHandler)
+	mux.HandleFunc("/api/protections", apiHandlers.ProtectionsHandler)
+	mux.HandleFunc("/api/bannedphrases", apiHandlers.BannedPhrasesHandler)
 	mux.HandleFunc("/api/botconfig", apiHandlers.BotConfigHandler)
 	mux.HandleFunc("/api/callback", apiHandlers.CallbackHandler)
+	mux.HandleFunc("/api/chatlogs", apiHandlers.ChatLogsHandler)
+	mux.HandleFunc("/api/commandregistry", apiHandlers.CommandRegistryHandler)
+	mux.HandleFunc("/api/commands", apiHandlers.CommandsHandler)
+	mux.HandleFunc("/api/commandsettings", apiHandlers.CommandSettingsHandler)
+	mux.HandleFunc("/api/dryrun", apiHandlers.DryRunHandler)
 	mux.HandleFunc("/api/emotehistory", apiHandlers.EmoteHistoryHandler)
+	mux.HandleFunc("/api/emoteusage", apiHandlers.EmoteUsageHandler)
 	mux.HandleFunc("/api/eventsub", apiHandlers.EventSubHandler)
+	mux.HandleFunc("/api/messagetemplates", apiHandlers.MessageTemplatesHandler)
+	mux.HandleFunc("/api/moderation", apiHandlers.ModerationHandler)
+	mux.HandleFunc("/api/predictions", apiHandlers.PredictionsHandler)
+	mux.HandleFunc("/api/predictionstats", apiHandlers.PredictionStatsHandler)
+	mux.HandleFunc("/api/quotes", apiHandlers.QuotesHandler)
 	mux.HandleFunc("/api/reward", apiHandlers.RewardHandler)
+	mux.HandleFunc("/api/streamsessions", apiHandlers.StreamSessionsHandler)
 	mux.HandleFunc("/api/subscriptions", apiHandlers.SubscriptionsHandler)
+	mux.HandleFunc("/api/timers", apiHandlers.TimersHandler)
 	mux.HandleFunc("/api/userconfig", apiHandlers.UserConfigHandler)
 	mux.HandleFunc("/api/ws", wsHandler.HandleWs)
 
@@ -0,0 +1,56 @@
+import { ArrowPathIcon, XMarkIcon } from "@heroicons/react/24/solid";
+import React, { useState } from "react";
+import { EmoteType } from "../../hooks/useEmotehistory";
+import { useProtections } from "../../hooks/useProtections";
+
+export function Protections() {
+    const { protections, protect, loading, fetch, deleteProtection } = useProtections();
+
+    const [newEmoteType, setNewEmoteType] = useState<EmoteType>(EmoteType.SEVENTV);
+    const [newEmotes, setNewEmotes] = useState<string>("");
+
+    const protectEmotes = () => {
+        if (newEmotes === "") {
+            return;
+        }
+
+        protect(newEmotes, newEmoteType);
+        setNewEmotes("");
+    };
+
+    return <div className="p-4">
+        <div className="p-4 bg-gray-800 rounded shadow relative">
+            <div className="flex gap-5 items-center mb-2">
+                <h2 className="text-xl">Protected</h2>
+                <ArrowPathIcon onClick={fetch} className={"h-6 hover:text-blue-500 cursor-pointer " + (loading ? "animate-spin" : "")} />
+            </div>
+            <p className="mb-5 text-gray-400">Emote rewards never remove these emotes, by emote ID or code.</p>
+            <table className={"w-full" + (loading ? " animate-pulse opacity-10" : "")}>
+                <thead>
+                    <tr className="border-b-8 border-transparent">
+                        <th />
+                        <th className="text-left pl-5">Emote</th>
+                        <th className="px-5">Type</th>
+                        <th className="px-5">Created</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {protections.map(protection => <tr key={protection.Type + protection.Emote}>
+                        <th className="hover:text-red-600 cursor-pointer" onClick={() => deleteProtection(protection)}><XMarkIcon className="h-6" /></th>
+                        <th>{protection.Emote}</th>
+                        <th>{protection.Type}</th>
+                        <th>{protection.CreatedAt.toLocaleDateString()} {protection.CreatedAt.toLocaleTimeString()}</th>
+                    </tr>)}
+                </tbody>
+            </table>
+            <div className="mt-5 flex gap-5">
+                <input type="text" placeholder="EmoteId,EmoteCode,EmoteId3" className="w-full p-1 bg-transparent leading-6 rounded" value={newEmotes} onChange={e => setNewEmotes(e.target.value)} />
+                <select className="p-1 pr-10 bg-transparent leading-6 rounded appearance-none" onChange={e => setNewEmoteType(e.target.value as EmoteType)} value={newEmoteType}>
+                    <option>{EmoteType.SEVENTV}</option>
+                    <option>{EmoteType.BTTV}</option>
+                </select>
+                <button className="bg-green-700 hover:bg-green-600 p-2 rounded shadow block cursor-pointer" onClick={protectEmotes}>protect</button>
+            </div>
+        </div>
+    </div>;
+}
@@ -2,6 +2,8 @@ import { useEffect, useState } from 'react';
 import { useBotConfig } from '../../hooks/useBotConfig';
 import { useSubscribtions } from '../../hooks/useSubscriptions';
 import { useStore } from '../../store';
+import { DryRun } from './DryRun';
+import { Messages } from './Messages';
 import { Toggle } from './Toggle';
 
 export function Bot() {
@@ -23,6 +25,21 @@ export function Bot() {
         }
     };
 
+    const [pollAnnouncements, setPollAnnouncements] = useState(false);
+
+    useEffect(() => {
+        setPollAnnouncements(subscriptionsStatus.polls);
+    }, [subscriptionsStatus.polls]);
+
+    const handlePollAnnouncementChange = (value: boolean) => {
+        setPollAnnouncements(value);
+        if (value) {
+            subscribe("polls");
+        } else {
+            unsubscribe("polls");
+        }
+    };
+
     const [botConfig, setBotConfig, loadingUserConfig] = useBotConfig();
     const handlePredictionCommandsChange = (value: boolean) => {
         if (botConfig) {
@@ -34,6 +51,16 @@ export function Bot() {
             setBotConfig({ ...botConfig, MediaCommands: value });
         }
     };
+    const handleAutoShoutoutRaidsChange = (value: boolean) => {
+        if (botConfig) {
+            setBotConfig({ ...botConfig, AutoShoutoutRaids: value });
+        }
+    };
+    const handleChatLogsChange = (value: boolean) => {
+        if (botConfig) {
+            setBotConfig({ ...botConfig, ChatLogs: value });
+        }
+    };
 
     return <div className={"p-4"}>
         <div className={"bg-gray-800 rounded shadow relative p-4 " + (loading ? "animate-pulse pointer-events-none" : "")}>
@@ -54,6 +81,21 @@ export function Bot() {
                 <Toggle checked={predictionsAnnouncements} onChange={handlePredictionAnnouncementChange} />
             </div>
         </div>
+        <div className={"bg-gray-800 rounded shadow relative p-4 mt-4 " + (loading ? "animate-pulse pointer-events-none" : "")}>
+            <div className="flex items-start justify-between">
+                <div>
+                    <h3 className="font-bold text-xl">Poll Announcements</h3>
+                    <div className="p-2 text-gray-200 mx-0 px-0">
+                        Announces when polls
+                        <ul className="list-disc pl-6 mt-2">
+                            <li>are made</li>
+                            <li>ended</li>
+                        </ul>
+                    </div>
+                </div>
+                <Toggle checked={pollAnnouncements} onChange={handlePollAnnouncementChange} />
+            </div>
+        </div>
         <div className={"bg-gray-800 rounded shadow relative p-4 mt-4 " + (loadingUserConfig ? "animate-pulse pointer-events-none" : "")}>
             <div className="flex items-start justify-between">
                 <div>
@@ -71,12 +113,43 @@ export function Bot() {
                             <li>!outcome 2</li>
                             <li>!outcome 10</li>
                             <li>!outcome yabbe</li>
+                            <li className="mt-2">!poll Best game?;2m;Minecraft;Factorio</li>
+                            <li>!poll end</li>
+                            <li>!poll archive</li>
                         </ul>
+                        {botConfig?.JoinBot && botConfig.JoinFailure && <div className="mt-2 text-red-400">
+                            The bot could not join your chat, it is {botConfig.JoinFailure}. Toggle it off and on again to retry.
+                        </div>}
                     </div>
                 </div>
                 <Toggle checked={!!botConfig?.JoinBot} onChange={handlePredictionCommandsChange} />
             </div>
         </div>
+        <div className={"bg-gray-800 rounded shadow relative p-4 mt-4 " + (loadingUserConfig ? "animate-pulse pointer-events-none" : "")}>
+            <div className="flex items-start justify-between">
+                <div>
+                    <h3 className="font-bold text-xl">Raid Shoutouts</h3>
+                    <div className="p-2 text-gray-200 mx-0 px-0">
+                        Shouts out raiders automatically, works like
+                        <ul className="list-disc pl-6 font-mono mt-2">
+                            <li>!so {"<user>"}</li>
+                        </ul>
+                    </div>
+                </div>
+                <Toggle checked={!!botConfig?.AutoShoutoutRaids} onChange={handleAutoShoutoutRaidsChange} />
+            </div>
+        </div>
+        <div className={"bg-gray-800 rounded shadow relative p-4 mt-4 " + (loadingUserConfig ? "animate-pulse pointer-events-none" : "")}>
+            <div className="flex items-start justify-between">
+                <div>
+                    <h3 className="font-bold text-xl">Chat Logs</h3>
+                    <div className="p-2 text-gray-200 mx-0 px-0">
+                        Stores the messages of your chat for a limited time, so you and your editors can look up what someone said.
+                    </div>
+                </div>
+                <Toggle checked={!!botConfig?.ChatLogs} onChange={handleChatLogsChange} />
+            </div>
+        </div>
         {isDev && <div className={"bg-gray-800 rounded shadow relative p-4 mt-4 " + (loadingUserConfig ? "animate-pulse pointer-events-none" : "")}>
             <div className="flex items-start justify-between">
                 <div>
@@ -90,6 +163,8 @@ export function Bot() {
                 <Toggle checked={!!botConfig?.MediaCommands} onChange={handleMediaCommandsChange} />
             </div>
         </div>}
+        <Messages />
+        <DryRun botConfig={botConfig} setBotConfig={setBotConfig} />
     </div >;
 }
 
@@ -0,0 +1,42 @@
+import { useEffect } from 'react';
+import { BotConfig, SetBotConfig } from '../../hooks/useBotConfig';
+import { useDryRun } from '../../hooks/useDryRun';
+import { Toggle } from './Toggle';
+
+export function DryRun({ botConfig, setBotConfig }: { botConfig: BotConfig | undefined, setBotConfig: SetBotConfig }) {
+    const { status, loading, fetchActions, clearActions } = useDryRun();
+
+    // eslint-disable-next-line react-hooks/exhaustive-deps
+    useEffect(fetchActions, [botConfig?.DryRun]);
+
+    const handleChange = (value: boolean) => {
+        if (botConfig) {
+            setBotConfig({ ...botConfig, DryRun: value });
+        }
+    };
+
+    return <div className={"bg-gray-800 rounded shadow relative p-4 mt-4 " + (loading ? "animate-pulse pointer-events-none" : "")}>
+        <div className="flex items-start justify-between">
+            <div>
+                <h3 className="font-bold text-xl">Dry Run</h3>
+                <div className="p-2 text-gray-200 mx-0 px-0">
+                    The bot only records what it would do: chat messages, emote changes, redemption updates and predictions.
+                    {status?.Global && <div className="mt-2 text-yellow-400">Dry run is enabled for the whole bot.</div>}
+                </div>
+            </div>
+            <Toggle checked={!!botConfig?.DryRun} onChange={handleChange} />
+        </div>
+        {(status?.Actions.length ?? 0) > 0 && <>
+            <table className="w-full table-auto mt-2">
+                <tbody>
+                    {status?.Actions.map(action => <tr key={action.ID} className="border-t border-gray-700">
+                        <td className="p-2 whitespace-nowrap text-gray-400">{new Date(action.CreatedAt).toLocaleString()}</td>
+                        <td className="p-2 font-mono">{action.Action}</td>
+                        <td className="p-2 break-all">{action.Details}</td>
+                    </tr>)}
+                </tbody>
+            </table>
+            <button onClick={clearActions} className="bg-red-700 hover:bg-red-600 p-2 rounded shadow mt-2 text-gray-100 cursor-pointer">Clear</button>
+        </>}
+    </div>;
+}
@@ -0,0 +1,59 @@
+import { useEffect, useState } from 'react';
+import { MessageTemplate, useMessageTemplates } from '../../hooks/useMessageTemplates';
+import { Toggle } from './Toggle';
+
+export function Messages() {
+    const { templates, loading, saveTemplate, resetTemplate } = useMessageTemplates();
+
+    return <div className={"bg-gray-800 rounded shadow relative p-4 mt-4 " + (loading ? "animate-pulse pointer-events-none" : "")}>
+        <h3 className="font-bold text-xl">Messages</h3>
+        <div className="p-2 text-gray-200 mx-0 px-0">
+            Customize or silence what the bot says in your chat. Leave a message empty to use the default.
+        </div>
+        <table className="w-full table-auto">
+            <tbody>
+                {templates.map(template => <MessageRow key={template.Key} template={template} saveTemplate={saveTemplate} resetTemplate={resetTemplate} />)}
+            </tbody>
+        </table>
+    </div>;
+}
+
+function MessageRow({ template, saveTemplate, resetTemplate }: { template: MessageTemplate, saveTemplate: (key: string, text: string, disabled: boolean) => void, resetTemplate: (key: string) => void }) {
+    const [text, setText] = useState(template.Text);
+
+    useEffect(() => {
+        setText(template.Text);
+    }, [template.Text]);
+
+    const save = () => {
+        if (text.trim() === "" && !template.Disabled) {
+            resetTemplate(template.Key);
+            return;
+        }
+        if (text !== template.Text) {
+            saveTemplate(template.Key, text, template.Disabled);
+        }
+    };
+
+    const toggle = (enabled: boolean) => {
+        if (enabled && text.trim() === "") {
+            resetTemplate(template.Key);
+            return;
+        }
+        saveTemplate(template.Key, text, !enabled);
+    };
+
+    return <tr className="border-t border-gray-700">
+        <td className="p-2 align-top w-1/3">
+            <div className="font-mono text-sm">{template.Key}</div>
+            <div className="text-gray-400 text-sm">{template.Description}</div>
+            <div className="text-gray-500 text-xs font-mono">{template.Placeholders.map(placeholder => `{${placeholder}}`).join(" ")}</div>
+        </td>
+        <td className="p-2 align-top">
+            <input type="text" className="w-full bg-gray-900 rounded p-2" placeholder={template.Default} value={text} onChange={e => setText(e.target.value)} onBlur={save} disabled={template.Disabled} />
+        </td>
+        <td className="p-2 align-top">
+            <Toggle checked={!template.Disabled} onChange={toggle} />
+        </td>
+    </tr>;
+}
@@ -10,12 +10,14 @@ export function MediaPlayer({ channel }: { channel: string }): JSX.Element {
     const player = useRef<ReactPlayer | null>(null);
 
     const tokenContent = useStore(state => state.scTokenContent);
-    const isChannelOwner = useRef(tokenContent?.Login === channel || channel === "");
+    const managing = useStore(state => state.managing);
+    // the server checks the media permission of managed channels
+    const canControl = useRef(tokenContent?.Login === channel || channel === "" || managing === channel);
     const [queue, setQueue] = useState<Queue>([]);
 
     useEffect(() => {
-        isChannelOwner.current = tokenContent?.Login === channel || channel === "";
-    }, [channel, tokenContent?.Login]);
+        canControl.current = tokenContent?.Login === channel || channel === "" || managing === channel;
+    }, [channel, tokenContent?.Login, managing]);
 
     const [url, setUrl] = useState();
     const [playing, setPlaying] = useState(false);
@@ -55,29 +57,29 @@ export function MediaPlayer({ channel }: { channel: string }): JSX.Element {
     }, []);
 
     const handlePause = () => {
-        if (!isChannelOwner.current) {
+        if (!canControl.current) {
             return;
         }
 
         const time = player.current?.getCurrentTime()
-        sendJsonMessage({ action: WsAction.PLAYER_STATE, time: time, url: url, state: PlayerState.PAUSED });
+        sendJsonMessage({ action: WsAction.PLAYER_STATE, channel: channel, time: time, url: url, state: PlayerState.PAUSED });
     }
 
     const handlePlay = () => {
-        if (!isChannelOwner.current) {
+        if (!canControl.current) {
             return;
         }
 
         const time = player.current?.getCurrentTime()
-        sendJsonMessage({ action: WsAction.PLAYER_STATE, time: time, url: url, state: PlayerState.PLAYING });
+        sendJsonMessage({ action: WsAction.PLAYER_STATE, channel: channel, time: time, url: url, state: PlayerState.PLAYING });
     }
 
     const handleSeek = (seconds: number) => {
-        if (!isChannelOwner.current) {
+        if (!canControl.current) {
             return;
         }
 
-        sendJsonMessage({ action: WsAction.PLAYER_STATE, time: seconds, url: url, state: PlayerState.PLAYING });
+        sendJsonMessage({ action: WsAction.PLAYER_STATE, channel: channel, time: seconds, url: url, state: PlayerState.PLAYING });
     }
 
     return <div className="flex gap-4 w-full h-full">
@@ -1,10 +1,13 @@
 import { XMarkIcon } from "@heroicons/react/24/solid";
 import { useEffect, useState } from "react";
 import { SubmitHandler, useForm } from "react-hook-form";
-import { Permission, SetUserConfig, UserConfig } from "../../hooks/useUserConfig";
+import { Capabilities, Permission, SetUserConfig, UserConfig } from "../../hooks/useUserConfig";
 import { isNumeric } from "../../service/isNumeric";
 
-type perms = { User: string, Editor: boolean, Prediction: boolean };
+type capabilityName = keyof typeof Capabilities;
+const capabilityNames = Object.keys(Capabilities) as Array<capabilityName>;
+
+type perms = { User: string } & Record<capabilityName, boolean>;
 
 export function UserPermissions({ userConfig, setUserConfig, errorMessage, loading }: { userConfig: UserConfig, setUserConfig: SetUserConfig, errorMessage?: string, loading?: boolean }) {
     const [perms, setPerms] = useState(userConfig.Permissions);
@@ -18,8 +21,9 @@ export function UserPermissions({ userConfig, setUserConfig, errorMessage, loadi
 
         for (const [user, perm] of Object.entries(userConfig.Permissions)) {
             setValue(`permissions.${user}.User`, user)
-            setValue(`permissions.${user}.Editor`, perm.Editor)
-            setValue(`permissions.${user}.Prediction`, perm.Prediction)
+            for (const name of capabilityNames) {
+                setValue(`permissions.${user}.${name}`, (perm.Capabilities & Capabilities[name]) !== 0)
+            }
         }
         // eslint-disable-next-line react-hooks/exhaustive-deps
     }, [JSON.stringify(userConfig.Permissions)]);
@@ -32,7 +36,14 @@ export function UserPermissions({ userConfig, setUserConfig, errorMessage, loadi
                 continue
             }
 
-            perms[perm.User.toLowerCase()] = { Editor: perm.Editor, Prediction: perm.Prediction };
+            let capabilities = 0;
+            for (const name of capabilityNames) {
+                if (perm[name]) {
+                    capabilities |= Capabilities[name];
+                }
+            }
+
+            perms[perm.User.toLowerCase()] = { Capabilities: capabilities };
         }
 
         setUserConfig({ ...userConfig, Permissions: perms })
@@ -40,7 +51,7 @@ export function UserPermissions({ userConfig, setUserConfig, errorMessage, loadi
 
     const addRow = () => {
         const newPerms = { ...perms };
-        newPerms["user" + addCounter] = { Editor: false, Prediction: true };
+        newPerms["user" + addCounter] = { Capabilities: Capabilities.Predictions };
 
         setPerms(newPerms);
         setAddCounter(addCounter + 1);
@@ -51,8 +62,9 @@ export function UserPermissions({ userConfig, setUserConfig, errorMessage, loadi
         delete newPerms[user];
 
         unregister(`permissions.${index}.User`);
-        unregister(`permissions.${index}.Editor`);
-        unregister(`permissions.${index}.Prediction`);
+        for (const name of capabilityNames) {
+            unregister(`permissions.${index}.${name}`);
+        }
 
         setPerms(newPerms);
     };
@@ -76,16 +88,14 @@ export function UserPermissions({ userConfig, setUserConfig, errorMessage, loadi
                 <tr className="border-b-8 border-transparent">
                     <th />
                     <th className="text-left pl-5">User</th>
-                    <th className="px-5">Editor</th>
-                    <th className="px-5">Prediction</th>
+                    {capabilityNames.map(name => <th key={name} className="px-2">{name}</th>)}
                 </tr>
             </thead>
             <tbody>
                 {Object.keys(perms).map((user, index) => <tr className={index % 2 ? "bg-gray-900" : ""} key={index}>
                     <th className="hover:text-red-600 cursor-pointer" onClick={() => removeRow(user, index)}><XMarkIcon className="h-6" /></th>
                     <th className="p-1"><input {...register(`permissions.${index}.User`)} className="p-1 bg-transparent leading-6" type="text" defaultValue={user} autoComplete={"off"} spellCheck={false} /> </th>
-                    <th className="p-1"><input {...register(`permissions.${index}.Editor`)} className="p-1 bg-transparent leading-6" type="checkbox" defaultChecked={perms[user].Editor} /></th>
-                    <th className="p-1"><input {...register(`permissions.${index}.Prediction`)} className="p-1 bg-transparent leading-6" type="checkbox" defaultChecked={perms[user].Prediction} /></th>
+                    {capabilityNames.map(name => <th key={name} className="p-1"><input {...register(`permissions.${index}.${name}`)} className="p-1 bg-transparent leading-6" type="checkbox" defaultChecked={(perms[user].Capabilities & Capabilities[name]) !== 0} /></th>)}
                 </tr>)}
             </tbody>
         </table>
@@ -2,9 +2,10 @@ import { useEffect, useState } from "react";
 import { useForm } from "react-hook-form";
 import { useChannelPointReward } from "../../../hooks/useChannelPointReward";
 import { UserConfig } from "../../../hooks/useUserConfig";
-import { ChannelPointReward, RewardTypes } from "../../../types/Rewards";
+import { ChannelPointReward, EmoteRemovalStrategy, RewardTypes } from "../../../types/Rewards";
+import { EmoteRulesFields, EmoteRulesForm, emoteRulesFromForm, setEmoteRulesValues } from "./EmoteRulesFields";
 
-interface BttvRewardForm {
+interface BttvRewardForm extends EmoteRulesForm {
     title: string;
     cost: string;
     approveOnly: boolean;
@@ -16,6 +17,8 @@ interface BttvRewardForm {
     enabled: boolean;
     isDefault: boolean;
     slots: number;
+    removalStrategy: EmoteRemovalStrategy;
+    expiresAfterDays: string;
 }
 
 const defaultReward = {
@@ -35,7 +38,7 @@ const defaultReward = {
     ShouldRedemptionsSkipRequestQueue: false,
     ApproveOnly: false,
     Enabled: false,
-    AdditionalOptionsParsed: { Slots: 1 }
+    AdditionalOptionsParsed: { Slots: 1, RemovalStrategy: EmoteRemovalStrategy.Fifo, ExpiresAfter: 0 }
 }
 
 export function BttvForm({ userConfig }: { userConfig: UserConfig }) {
@@ -61,7 +64,10 @@ export function BttvForm({ userConfig }: { userConfig: UserConfig }) {
             ShouldRedemptionsSkipRequestQueue: false,
             Enabled: data.enabled,
             AdditionalOptionsParsed: {
-                Slots: Number(data.slots)
+                Slots: Number(data.slots),
+                RemovalStrategy: data.removalStrategy,
+                ExpiresAfter: Math.round(Number(data.expiresAfterDays) * 86400),
+                Rules: emoteRulesFromForm(data)
             }
         };
 
@@ -73,6 +79,9 @@ export function BttvForm({ userConfig }: { userConfig: UserConfig }) {
         setValue("prompt", reward.Prompt);
         setValue("cost", reward.Cost);
         setValue("slots", reward.AdditionalOptionsParsed.Slots);
+        setValue("removalStrategy", reward.AdditionalOptionsParsed.RemovalStrategy || EmoteRemovalStrategy.Fifo);
+        setValue("expiresAfterDays", (reward.AdditionalOptionsParsed.ExpiresAfter ?? 0) / 86400);
+        setEmoteRulesValues(setValue, reward.AdditionalOptionsParsed.Rules);
         setValue("backgroundColor", reward.BackgroundColor);
         setValue("maxPerStream", reward.MaxPerStream);
         setValue("maxPerUserPerStream", reward.MaxPerUserPerStream);
@@ -101,7 +110,7 @@ export function BttvForm({ userConfig }: { userConfig: UserConfig }) {
             </div>
             <p className="my-2 mb-4 text-gray-400">
                 <strong>Make sure <span className="text-green-600">gempbot</span> is BetterTTV editor</strong><br />
-                This will swap out emotes constantly. The amount of slots it manages is configurable and the oldest added emote by the bot will be removed first.
+                This will swap out emotes constantly. The amount of slots it manages is configurable and the removal strategy decides which emote added by the bot makes room for a new one.
             </p>
             <label className="block my-3">
                 Slots
@@ -109,6 +118,22 @@ export function BttvForm({ userConfig }: { userConfig: UserConfig }) {
                 {errors.cost && <span className="text-red-700">required</span>}
             </label>
 
+            <label className="block my-3">
+                Removal
+                <select {...register("removalStrategy")} className="form-select border-none bg-gray-700 mx-2 p-2 pr-8 rounded shadow">
+                    <option value={EmoteRemovalStrategy.Fifo}>oldest added</option>
+                    <option value={EmoteRemovalStrategy.LeastRecentlyUsed}>least recently used in chat</option>
+                    <option value={EmoteRemovalStrategy.LeastUsed}>least used in chat</option>
+                    <option value={EmoteRemovalStrategy.Random}>random</option>
+                    <option value={EmoteRemovalStrategy.Refuse}>refuse when full</option>
+                </select>
+            </label>
+
+            <label className="block my-3">
+                Expires after days <span className="text-gray-500">(0 = until replaced)</span>
+                <input defaultValue={(reward.AdditionalOptionsParsed.ExpiresAfter ?? 0) / 86400} placeholder="0" type="number" min="0" step="any" spellCheck={false} {...register("expiresAfterDays")} className="form-input border-none bg-gray-700 mx-2 p-2 rounded shadow" />
+            </label>
+
             <label className="block">
                 Title
                 <input defaultValue={reward.Title} spellCheck={false} {...register("title", { required: true })} className="form-input border-none bg-gray-700 mx-2 p-2 rounded shadow" />
@@ -153,6 +178,8 @@ export function BttvForm({ userConfig }: { userConfig: UserConfig }) {
                 <span className="ml-2">Approve only <span className="text-gray-500">will only activate the reward when it's marked as complete by a moderator. Rejects will be blocked</span></span>
             </label>
 
+            <EmoteRulesFields register={register} sevenTv={false} />
+
             {errorMessage && <div className="p-4 text-red-800">
                 {errorMessage}
             </div>}
@@ -0,0 +1,80 @@
+import { FieldValues, UseFormRegister, UseFormSetValue } from "react-hook-form";
+import { EmoteRules } from "../../../types/Rewards";
+
+export interface EmoteRulesForm {
+    denyAnimated: boolean;
+    denyZeroWidth: boolean;
+    denyUnlisted: boolean;
+    allowedCodePattern: string;
+    deniedCodePattern: string;
+    deniedUploaders: string;
+    maxCodeLength: string;
+}
+
+export function emoteRulesFromForm(data: EmoteRulesForm): EmoteRules {
+    return {
+        DenyAnimated: Boolean(data.denyAnimated),
+        DenyZeroWidth: Boolean(data.denyZeroWidth),
+        DenyUnlisted: Boolean(data.denyUnlisted),
+        AllowedCodePattern: data.allowedCodePattern ?? "",
+        DeniedCodePattern: data.deniedCodePattern ?? "",
+        DeniedUploaders: (data.deniedUploaders ?? "").split(",").map(uploader => uploader.trim()).filter(uploader => uploader !== ""),
+        MaxCodeLength: Number(data.maxCodeLength),
+    };
+}
+
+export function setEmoteRulesValues(setValue: UseFormSetValue<FieldValues>, rules?: EmoteRules) {
+    setValue("denyAnimated", rules?.DenyAnimated ?? false);
+    setValue("denyZeroWidth", rules?.DenyZeroWidth ?? false);
+    setValue("denyUnlisted", rules?.DenyUnlisted ?? false);
+    setValue("allowedCodePattern", rules?.AllowedCodePattern ?? "");
+    setValue("deniedCodePattern", rules?.DeniedCodePattern ?? "");
+    setValue("deniedUploaders", (rules?.DeniedUploaders ?? []).join(","));
+    setValue("maxCodeLength", rules?.MaxCodeLength ?? 0);
+}
+
+// EmoteRulesFields are the emote validation rules of a reward, BTTV has no zero-width or unlisted shared emotes
+export function EmoteRulesFields({ register, sevenTv }: { register: UseFormRegister<FieldValues>, sevenTv: boolean }) {
+    const checkboxClassName = "form-checkbox rounded border-gray-300 text-indigo-600 shadow-sm focus:border-indigo-300 focus:ring focus:ring-offset-0 focus:ring-indigo-200 focus:ring-opacity-50";
+
+    return <>
+        <div className="mt-8 font-bold">Emote Rules <span className="text-gray-500">(redemptions breaking a rule are refunded)</span></div>
+
+        <label className="flex items-center mt-3">
+            <input type="checkbox" {...register("denyAnimated")} className={checkboxClassName} />
+            <span className="ml-2">Deny animated emotes</span>
+        </label>
+
+        {sevenTv && <>
+            <label className="flex items-center mt-3">
+                <input type="checkbox" {...register("denyZeroWidth")} className={checkboxClassName} />
+                <span className="ml-2">Deny zero-width emotes</span>
+            </label>
+
+            <label className="flex items-center mt-3">
+                <input type="checkbox" {...register("denyUnlisted")} className={checkboxClassName} />
+                <span className="ml-2">Deny unlisted emotes</span>
+            </label>
+        </>}
+
+        <label className="flex items-center mt-3">
+            Allowed Code Regex
+            <input placeholder="^[A-Za-z]+$" spellCheck={false} {...register("allowedCodePattern")} className="form-input border-none bg-gray-700 mx-2 p-2 rounded shadow" />
+        </label>
+
+        <label className="flex items-center mt-3">
+            Denied Code Regex
+            <input placeholder="(?i)nam" spellCheck={false} {...register("deniedCodePattern")} className="form-input border-none bg-gray-700 mx-2 p-2 rounded shadow" />
+        </label>
+
+        <label className="flex items-center mt-3">
+            Denied Uploader IDs
+            <input placeholder="UploaderId,UploaderId2" spellCheck={false} {...register("deniedUploaders")} className="form-input w-full border-none bg-gray-700 mx-2 p-2 rounded shadow" />
+        </label>
+
+        <label className="flex items-center my-3">
+            Max Code Length <span className="text-gray-500 ml-1">(0 = unlimited)</span>
+            <input placeholder="0" type="number" min="0" spellCheck={false} {...register("maxCodeLength")} className="form-input border-none bg-gray-700 mx-2 p-2 rounded shadow" />
+        </label>
+    </>;
+}
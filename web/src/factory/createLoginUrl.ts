@@ -3,7 +3,7 @@ export function createLoginUrl(apiBaseUrl: string, twitchClientId: string): URL
     url.searchParams.set("client_id", twitchClientId);
     url.searchParams.set("redirect_uri", apiBaseUrl + "/api/callback");
     url.searchParams.set("response_type", "code");
-    url.searchParams.set("scope", "channel:read:redemptions channel:manage:redemptions channel:read:predictions channel:manage:predictions moderation:read");
+    url.searchParams.set("scope", "channel:read:redemptions channel:manage:redemptions channel:read:predictions channel:manage:predictions moderation:read channel:read:polls channel:manage:polls moderator:manage:banned_users moderator:manage:chat_messages moderator:manage:shoutouts moderator:manage:announcements");
 
     return url;
 }
\ No newline at end of file
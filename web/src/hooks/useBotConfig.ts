@@ -6,6 +6,12 @@ export interface BotConfig {
     OwnerTwitchId: string;
     JoinBot: boolean;
     MediaCommands: boolean;
+    CommandPrefix: string;
+    AutoShoutoutRaids: boolean;
+    JoinFailure: string;
+    DryRun: boolean;
+    ChatLogs: boolean;
+    JoinState?: "pending" | "joined" | "banned" | "suspended" | "";
 }
 
 export type SetBotConfig = (config: BotConfig) => void;
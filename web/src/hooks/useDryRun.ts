@@ -0,0 +1,54 @@
+import { useEffect, useState } from "react";
+import { doFetch, Method } from "../service/doFetch";
+import { useStore } from "../store";
+
+export interface DryRunAction {
+    ID: number;
+    ChannelTwitchID: string;
+    Action: string;
+    Details: string;
+    CreatedAt: string;
+}
+
+export interface DryRunStatus {
+    Enabled: boolean;
+    Global: boolean;
+    Actions: Array<DryRunAction>;
+}
+
+interface Return {
+    status: DryRunStatus | undefined,
+    loading: boolean,
+    fetchActions: () => void,
+    clearActions: () => void,
+}
+
+export function useDryRun(): Return {
+    const [status, setStatus] = useState<DryRunStatus | undefined>(undefined);
+    const [loading, setLoading] = useState(true);
+    const managing = useStore(state => state.managing);
+    const apiBaseUrl = useStore(state => state.apiBaseUrl);
+    const scToken = useStore(state => state.scToken);
+
+    const endPoint = "/api/dryrun";
+
+    const fetchActions = () => {
+        setLoading(true);
+        doFetch({ apiBaseUrl, managing, scToken }, Method.GET, endPoint).then(setStatus).then(() => setLoading(false)).catch(() => setLoading(false));
+    };
+
+    // eslint-disable-next-line react-hooks/exhaustive-deps
+    useEffect(fetchActions, [managing]);
+
+    const clearActions = () => {
+        setLoading(true);
+        doFetch({ apiBaseUrl, managing, scToken }, Method.DELETE, endPoint).then(fetchActions).catch(() => setLoading(false));
+    };
+
+    return {
+        status: status,
+        loading: loading,
+        fetchActions: fetchActions,
+        clearActions: clearActions,
+    };
+}
@@ -0,0 +1,56 @@
+import { useEffect, useState } from "react";
+import { doFetch, Method } from "../service/doFetch";
+import { useStore } from "../store";
+
+export interface MessageTemplate {
+    Key: string;
+    Description: string;
+    Default: string;
+    Placeholders: Array<string>;
+    Text: string;
+    Disabled: boolean;
+}
+
+interface Return {
+    templates: Array<MessageTemplate>,
+    loading: boolean,
+    saveTemplate: (key: string, text: string, disabled: boolean) => void,
+    resetTemplate: (key: string) => void,
+}
+
+export function useMessageTemplates(): Return {
+    const [templates, setTemplates] = useState<Array<MessageTemplate>>([]);
+    const [loading, setLoading] = useState(true);
+    const managing = useStore(state => state.managing);
+    const apiBaseUrl = useStore(state => state.apiBaseUrl);
+    const scToken = useStore(state => state.scToken);
+
+    const endPoint = "/api/messagetemplates";
+
+    const fetchTemplates = () => {
+        setLoading(true);
+        doFetch({ apiBaseUrl, managing, scToken }, Method.GET, endPoint).then(setTemplates).then(() => setLoading(false)).catch(() => setLoading(false));
+    };
+
+    // eslint-disable-next-line react-hooks/exhaustive-deps
+    useEffect(fetchTemplates, [managing]);
+
+    const saveTemplate = (key: string, text: string, disabled: boolean) => {
+        setLoading(true);
+        doFetch({ apiBaseUrl, managing, scToken }, Method.POST, endPoint, undefined, { Key: key, Text: text, Disabled: disabled }).then(fetchTemplates).catch(() => setLoading(false));
+    };
+
+    const resetTemplate = (key: string) => {
+        setLoading(true);
+        const searchParams = new URLSearchParams();
+        searchParams.append("key", key);
+        doFetch({ apiBaseUrl, managing, scToken }, Method.DELETE, endPoint, searchParams).then(fetchTemplates).catch(() => setLoading(false));
+    };
+
+    return {
+        templates: templates,
+        loading: loading,
+        saveTemplate: saveTemplate,
+        resetTemplate: resetTemplate,
+    };
+}
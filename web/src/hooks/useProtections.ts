@@ -0,0 +1,71 @@
+import { useEffect, useState } from "react";
+import { doFetch, Method } from "../service/doFetch";
+import { useStore } from "../store";
+import { EmoteType } from "./useEmotehistory";
+
+interface RawProtection {
+    ChannelTwitchID: string
+    Type: EmoteType
+    Emote: string
+    CreatedAt: string
+}
+
+export type Protection = RawProtection & {
+    CreatedAt: Date,
+}
+
+interface Return {
+    protections: Array<Protection>,
+    fetch: () => void,
+    loading: boolean,
+    protect: (emotes: string, type: EmoteType) => void,
+    deleteProtection: (protection: Protection) => void,
+}
+
+export function useProtections(): Return {
+    const [protections, setProtections] = useState<Array<Protection>>([]);
+    const [loading, setLoading] = useState(false);
+    const managing = useStore(state => state.managing);
+    const apiBaseUrl = useStore(state => state.apiBaseUrl);
+    const scToken = useStore(state => state.scToken);
+
+    const fetchProtections = () => {
+        setLoading(true);
+
+        const endPoint = "/api/protections";
+        doFetch({apiBaseUrl, managing, scToken}, Method.GET, endPoint)
+            .then(rawProtections => setProtections(rawProtections.map((rawProtection: RawProtection) => ({ ...rawProtection, CreatedAt: new Date(rawProtection.CreatedAt) }))))
+            .then(() => setLoading(false)).catch(() => setLoading(false));
+    };
+
+    // eslint-disable-next-line react-hooks/exhaustive-deps
+    useEffect(fetchProtections, [managing]);
+
+    const protect = (emotes: string, type: EmoteType) => {
+        setLoading(true);
+
+        const endPoint = "/api/protections";
+        doFetch({apiBaseUrl, managing, scToken}, Method.PATCH, endPoint, undefined, { emotes: emotes, type: type }).then(fetchProtections).catch(err => {
+            setLoading(false);
+            throw err;
+        });
+    };
+
+    const deleteProtection = (protection: Protection) => {
+        setLoading(true);
+
+        const endPoint = "/api/protections";
+        doFetch({apiBaseUrl, managing, scToken}, Method.DELETE, endPoint, undefined, protection).then(fetchProtections).catch(err => {
+            setLoading(false);
+            throw err;
+        });
+    };
+
+    return {
+        protections: protections,
+        fetch: fetchProtections,
+        loading: loading,
+        protect: protect,
+        deleteProtection: deleteProtection,
+    };
+}
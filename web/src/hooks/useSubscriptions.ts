@@ -4,37 +4,41 @@ import { useStore } from "../store";
 
 interface SubscriptionStatus {
     predictions: boolean;
+    polls: boolean;
 }
 
-export function useSubscribtions(): [() => void, () => void, SubscriptionStatus, boolean] {
+export type SubscriptionType = "predictions" | "polls";
+
+export function useSubscribtions(): [(type?: SubscriptionType) => void, (type?: SubscriptionType) => void, SubscriptionStatus, boolean] {
     const managing = useStore(state => state.managing);
     const [loading, setLoading] = useState(true);
-    const [subscriptionStatus, setSubscriptionStatus] = useState<SubscriptionStatus>({ predictions: false });
+    const [subscriptionStatus, setSubscriptionStatus] = useState<SubscriptionStatus>({ predictions: false, polls: false });
     const apiBaseUrl = useStore(state => state.apiBaseUrl);
     const scToken = useStore(state => state.scToken);
 
-    const executeSubscriptions = (method: Method) => {
+    const executeSubscriptions = (method: Method, type: SubscriptionType = "predictions") => {
         const endPoint = "/api/subscriptions";
         const searchParams = new URLSearchParams();
         if (managing) {
             searchParams.append("managing", managing);
         }
+        searchParams.append("type", type);
 
         return doFetch({apiBaseUrl, managing, scToken }, method, endPoint, searchParams);
     };
 
-    const subscribe = () => {
+    const subscribe = (type: SubscriptionType = "predictions") => {
         setLoading(true);
 
-        executeSubscriptions(Method.PUT).then(() => setSubscriptionStatus({predictions: true})).then(() => setLoading(false)).catch(err => {
+        executeSubscriptions(Method.PUT, type).then(() => setSubscriptionStatus({ ...subscriptionStatus, [type]: true })).then(() => setLoading(false)).catch(err => {
             console.error(err);
             setLoading(false);
         });
     }
-    const remove = () => {
+    const remove = (type: SubscriptionType = "predictions") => {
         setLoading(true);
 
-        executeSubscriptions(Method.DELETE).then(() => setSubscriptionStatus({predictions: false})).then(() => setLoading(false)).catch(err => {
+        executeSubscriptions(Method.DELETE, type).then(() => setSubscriptionStatus({ ...subscriptionStatus, [type]: false })).then(() => setLoading(false)).catch(err => {
             console.error(err);
             setLoading(false);
         });
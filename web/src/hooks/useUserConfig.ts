@@ -9,10 +9,21 @@ export interface UserConfig {
 }
 
 export interface Permission {
-    Editor: boolean;
-    Prediction: boolean;
+    Capabilities: number;
 }
 
+export const Capabilities = {
+    Predictions: 1 << 0,
+    Media: 1 << 1,
+    Emotes: 1 << 2,
+    Blocks: 1 << 3,
+    Rewards: 1 << 4,
+    Commands: 1 << 5,
+    BotConfig: 1 << 6,
+    Permissions: 1 << 7,
+    Moderation: 1 << 8,
+};
+
 export interface Rewards {
     Bttv: null | BttvReward
 }
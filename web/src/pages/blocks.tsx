@@ -1,9 +1,13 @@
 import React from "react";
 import { Blocks as BlocksPage } from "../components/Blocks/Blocks";
+import { Protections } from "../components/Blocks/Protections";
 import { initializeStore } from "../service/initializeStore";
 
 export default function Blocks() {
-    return <BlocksPage />
+    return <>
+        <BlocksPage />
+        <Protections />
+    </>
 }
 
 export const getServerSideProps = initializeStore;
\ No newline at end of file
@@ -3,6 +3,14 @@ export enum RewardTypes {
     SevenTv = "seventv",
 }
 
+export enum EmoteRemovalStrategy {
+    Fifo = "fifo",
+    LeastRecentlyUsed = "least_recently_used",
+    LeastUsed = "least_used",
+    Random = "random",
+    Refuse = "refuse",
+}
+
 export interface ChannelPointReward {
     OwnerTwitchID: string
     ApproveOnly: boolean
@@ -30,4 +38,17 @@ export interface RawBttvChannelPointReward extends ChannelPointReward {
 
 export interface BttvAdditionalOptions {
     Slots: number
+    RemovalStrategy?: EmoteRemovalStrategy
+    ExpiresAfter?: number
+    Rules?: EmoteRules
+}
+
+export interface EmoteRules {
+    DenyAnimated: boolean
+    DenyZeroWidth: boolean
+    DenyUnlisted: boolean
+    AllowedCodePattern: string
+    DeniedCodePattern: string
+    DeniedUploaders: Array<string>
+    MaxCodeLength: number
 }
\ No newline at end of file